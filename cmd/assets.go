@@ -26,7 +26,7 @@ var assetsListCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
 		}
 
 		workspaceID := GetWorkspaceID()
@@ -45,15 +45,14 @@ var assetsListCmd = &cobra.Command{
 		req, _ := http.NewRequest("GET", url, nil)
 		req.Header.Set("Authorization", apiKey)
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := doRequest(req)
 		if err != nil {
 			return fmt.Errorf("request failed: %w", err)
 		}
 		defer resp.Body.Close()
 
 		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+			return newAPIError(resp)
 		}
 
 		var result struct {
@@ -97,13 +96,13 @@ var assetsListCmd = &cobra.Command{
 var assetsUploadCmd = &cobra.Command{
 	Use:   "upload [file-path]",
 	Short: "Upload an asset",
-	Args:  cobra.ExactArgs(1),
+	Args:  usageArgs(cobra.ExactArgs(1)),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		filePath := args[0]
 
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
 		}
 
 		workspaceID := GetWorkspaceID()
@@ -111,94 +110,107 @@ var assetsUploadCmd = &cobra.Command{
 			return fmt.Errorf("no workspace configured. Run 'hy auth login' first")
 		}
 
-		// Read file info
-		fileInfo, err := os.Stat(filePath)
+		assetType, _ := cmd.Flags().GetString("type")
+		name, _ := cmd.Flags().GetString("name")
+
+		assetID, err := uploadAsset(apiKey, workspaceID, filePath, name, assetType, os.Stdout)
 		if err != nil {
-			return fmt.Errorf("cannot access file: %w", err)
+			return err
 		}
 
-		fileName := filepath.Base(filePath)
-		mimeType := detectMimeType(filePath)
-		assetType := detectAssetType(mimeType)
+		fmt.Printf("✓ Uploaded: %s\n", assetID)
+		return nil
+	},
+}
 
-		// Allow override
-		if t, _ := cmd.Flags().GetString("type"); t != "" {
-			assetType = t
-		}
-		if n, _ := cmd.Flags().GetString("name"); n != "" {
-			fileName = n
-		}
+// uploadAsset creates an asset and uploads the file to its signed URL,
+// returning the asset ID. name and assetType default to the file name and
+// the type detected from its extension. Progress is written to out.
+func uploadAsset(apiKey, workspaceID, filePath, name, assetType string, out io.Writer) (string, error) {
+	// Read file info
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return "", fmt.Errorf("cannot access file: %w", err)
+	}
 
-		fmt.Printf("Uploading %s (%s, %s)...\n", fileName, assetType, formatBytes(fileInfo.Size()))
+	fileName := filepath.Base(filePath)
+	mimeType := detectMimeType(filePath)
 
-		// Step 1: Create asset and get upload URL
-		payload := map[string]interface{}{
-			"name":      fileName,
-			"type":      assetType,
-			"mimeType":  mimeType,
-			"sizeBytes": fileInfo.Size(),
-		}
-		body, _ := json.Marshal(payload)
+	// Allow override
+	if assetType == "" {
+		assetType = detectAssetType(mimeType)
+	}
+	if name != "" {
+		fileName = name
+	}
 
-		url := fmt.Sprintf("%s/workspaces/%s/assets", GetAPIURL(), workspaceID)
-		req, _ := http.NewRequest("POST", url, bytes.NewReader(body))
-		req.Header.Set("Authorization", apiKey)
-		req.Header.Set("Content-Type", "application/json")
+	fmt.Fprintf(out, "Uploading %s (%s, %s)...\n", fileName, assetType, formatBytes(fileInfo.Size()))
 
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return fmt.Errorf("request failed: %w", err)
-		}
-		defer resp.Body.Close()
+	// Step 1: Create asset and get upload URL
+	payload := map[string]interface{}{
+		"name":      fileName,
+		"type":      assetType,
+		"mimeType":  mimeType,
+		"sizeBytes": fileInfo.Size(),
+	}
+	body, _ := json.Marshal(payload)
 
-		if resp.StatusCode != http.StatusCreated {
-			respBody, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
-		}
+	url := fmt.Sprintf("%s/workspaces/%s/assets", GetAPIURL(), workspaceID)
+	req, _ := http.NewRequest("POST", url, bytes.NewReader(body))
+	req.Header.Set("Authorization", apiKey)
+	setIdempotencyKey(req)
+	req.Header.Set("Content-Type", "application/json")
 
-		var createResult struct {
-			ID        string `json:"id"`
-			UploadURL string `json:"uploadUrl"`
-		}
-		json.NewDecoder(resp.Body).Decode(&createResult)
+	resp, err := doRequest(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
 
-		// Step 2: Upload file to signed URL
-		file, err := os.Open(filePath)
-		if err != nil {
-			return fmt.Errorf("cannot open file: %w", err)
-		}
-		defer file.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", newAPIError(resp)
+	}
 
-		uploadReq, _ := http.NewRequest("PUT", createResult.UploadURL, file)
-		uploadReq.Header.Set("Content-Type", mimeType)
-		uploadReq.ContentLength = fileInfo.Size()
+	var createResult struct {
+		ID        string `json:"id"`
+		UploadURL string `json:"uploadUrl"`
+	}
+	json.NewDecoder(resp.Body).Decode(&createResult)
 
-		uploadResp, err := http.DefaultClient.Do(uploadReq)
-		if err != nil {
-			return fmt.Errorf("upload failed: %w", err)
-		}
-		defer uploadResp.Body.Close()
+	// Step 2: Upload file to signed URL
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("cannot open file: %w", err)
+	}
+	defer file.Close()
 
-		if uploadResp.StatusCode != http.StatusOK && uploadResp.StatusCode != http.StatusCreated {
-			respBody, _ := io.ReadAll(uploadResp.Body)
-			return fmt.Errorf("upload failed (%d): %s", uploadResp.StatusCode, string(respBody))
-		}
+	uploadReq, _ := http.NewRequest("PUT", createResult.UploadURL, file)
+	uploadReq.Header.Set("Content-Type", mimeType)
+	uploadReq.ContentLength = fileInfo.Size()
 
-		fmt.Printf("✓ Uploaded: %s\n", createResult.ID)
-		return nil
-	},
+	uploadResp, err := doRequest(uploadReq)
+	if err != nil {
+		return "", fmt.Errorf("upload failed: %w", err)
+	}
+	defer uploadResp.Body.Close()
+
+	if uploadResp.StatusCode != http.StatusOK && uploadResp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("upload failed: %w", newAPIError(uploadResp))
+	}
+
+	return createResult.ID, nil
 }
 
 var assetsGetCmd = &cobra.Command{
 	Use:   "get [asset-id]",
 	Short: "Get asset details and download URL",
-	Args:  cobra.ExactArgs(1),
+	Args:  usageArgs(cobra.ExactArgs(1)),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		assetID := args[0]
 
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
 		}
 
 		workspaceID := GetWorkspaceID()
@@ -207,15 +219,14 @@ var assetsGetCmd = &cobra.Command{
 		req, _ := http.NewRequest("GET", url, nil)
 		req.Header.Set("Authorization", apiKey)
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := doRequest(req)
 		if err != nil {
 			return fmt.Errorf("request failed: %w", err)
 		}
 		defer resp.Body.Close()
 
 		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+			return newAPIError(resp)
 		}
 
 		var result struct {
@@ -246,13 +257,13 @@ var assetsGetCmd = &cobra.Command{
 var assetsDeleteCmd = &cobra.Command{
 	Use:   "delete [asset-id]",
 	Short: "Delete an asset",
-	Args:  cobra.ExactArgs(1),
+	Args:  usageArgs(cobra.ExactArgs(1)),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		assetID := args[0]
 
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
 		}
 
 		workspaceID := GetWorkspaceID()
@@ -273,15 +284,14 @@ var assetsDeleteCmd = &cobra.Command{
 		req, _ := http.NewRequest("DELETE", url, nil)
 		req.Header.Set("Authorization", apiKey)
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := doRequest(req)
 		if err != nil {
 			return fmt.Errorf("request failed: %w", err)
 		}
 		defer resp.Body.Close()
 
 		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+			return newAPIError(resp)
 		}
 
 		fmt.Printf("✓ Deleted asset: %s\n", assetID)
@@ -1,19 +1,30 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
+	"io"
 	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/exec"
 	"runtime"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"github.com/zalando/go-keyring"
 )
 
 const (
@@ -32,11 +43,36 @@ var authLoginCmd = &cobra.Command{
 	Long: `Authenticate via browser login.
 
 This will open your browser to sign in with Hypewell Studio.
-An API key will be created automatically and stored securely.`,
+An API key will be created automatically and stored securely.
+
+The browser returns a one-time code bound to this login (state nonce and
+PKCE challenge), which the CLI exchanges for the key, so the key itself
+never appears in a URL.
+
+Use --device on machines without a browser (SSH sessions, containers, CI):
+the CLI prints a short code to enter at a URL on any other device.
+
+Use --with-token to store an existing API key read from stdin. The key is
+validated against the API before it is saved:
+
+  echo "$HY_TOKEN" | hy auth login --with-token`,
 	RunE: runAuthLogin,
 }
 
 func runAuthLogin(cmd *cobra.Command, args []string) error {
+	device, _ := cmd.Flags().GetBool("device")
+	withToken, _ := cmd.Flags().GetBool("with-token")
+
+	if device && withToken {
+		return fmt.Errorf("--device and --with-token cannot be used together")
+	}
+	if withToken {
+		return runTokenLogin()
+	}
+	if device {
+		return runDeviceLogin()
+	}
+
 	// Find an available port
 	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
@@ -45,40 +81,24 @@ func runAuthLogin(cmd *cobra.Command, args []string) error {
 	port := listener.Addr().(*net.TCPAddr).Port
 	listener.Close()
 
+	// The state nonce ties the callback to this login attempt, and the PKCE
+	// verifier ensures only this process can redeem the returned code
+	state, err := randomToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate state: %w", err)
+	}
+	verifier, err := randomToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+
 	// Channel to receive the result
 	resultCh := make(chan authResult, 1)
 
 	// Start local HTTP server
 	server := &http.Server{
-		Addr: fmt.Sprintf("127.0.0.1:%d", port),
-		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if r.URL.Path != "/callback" {
-				http.NotFound(w, r)
-				return
-			}
-
-			apiKey := r.URL.Query().Get("key")
-			workspaceID := r.URL.Query().Get("workspace")
-			errorMsg := r.URL.Query().Get("error")
-
-			if errorMsg != "" {
-				resultCh <- authResult{err: fmt.Errorf(errorMsg)}
-				w.Header().Set("Content-Type", "text/html")
-				fmt.Fprintf(w, `<html><body><h1>❌ Authentication failed</h1><p>%s</p><p>You can close this window.</p></body></html>`, errorMsg)
-				return
-			}
-
-			if apiKey == "" || workspaceID == "" {
-				resultCh <- authResult{err: fmt.Errorf("missing credentials in callback")}
-				w.Header().Set("Content-Type", "text/html")
-				fmt.Fprint(w, `<html><body><h1>❌ Authentication failed</h1><p>Missing credentials.</p><p>You can close this window.</p></body></html>`)
-				return
-			}
-
-			resultCh <- authResult{apiKey: apiKey, workspaceID: workspaceID}
-			w.Header().Set("Content-Type", "text/html")
-			fmt.Fprint(w, `<html><body><h1>✅ Authenticated!</h1><p>You can close this window and return to the terminal.</p><script>window.close()</script></body></html>`)
-		}),
+		Addr:    fmt.Sprintf("127.0.0.1:%d", port),
+		Handler: newAuthCallbackHandler(state, resultCh),
 	}
 
 	go func() {
@@ -88,11 +108,12 @@ func runAuthLogin(cmd *cobra.Command, args []string) error {
 	}()
 
 	// Build the auth URL
-	apiURL := GetAPIURL()
-	// Remove /api suffix to get base URL
-	baseURL := strings.TrimSuffix(apiURL, "/api")
-	baseURL = strings.TrimSuffix(baseURL, "/")
-	authURL := fmt.Sprintf("%s/cli/auth?port=%d", baseURL, port)
+	query := url.Values{}
+	query.Set("port", strconv.Itoa(port))
+	query.Set("state", state)
+	query.Set("code_challenge", codeChallenge(verifier))
+	query.Set("code_challenge_method", "S256")
+	authURL := fmt.Sprintf("%s/cli/auth?%s", webBaseURL(), query.Encode())
 
 	fmt.Println("Opening browser to authenticate...")
 	fmt.Printf("If browser doesn't open, visit: %s\n\n", authURL)
@@ -116,32 +137,340 @@ func runAuthLogin(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("authentication failed: %w", result.err)
 		}
 
-		// Store API key in keyring
-		if err := keyring.Set(serviceName, keyringUser, result.apiKey); err != nil {
-			// Fallback: store in config file (less secure)
-			fmt.Println("Warning: Could not store in system keyring, storing in config file")
-			viper.Set("api_key", result.apiKey)
-		}
-
-		// Store workspace ID in config
-		viper.Set("workspace_id", result.workspaceID)
-		if err := viper.WriteConfig(); err != nil {
-			if err := viper.SafeWriteConfig(); err != nil {
-				return fmt.Errorf("failed to save config: %w", err)
-			}
+		// Redeem the one-time code for the API key
+		creds, err := exchangeAuthCode(result.code, verifier)
+		if err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
 		}
 
-		fmt.Println("\n✓ Authenticated successfully")
-		fmt.Printf("  Workspace: %s\n", result.workspaceID)
-		return nil
-
+		return storeCredentials(creds)
 	case <-ctx.Done():
 		server.Shutdown(context.Background())
 		return fmt.Errorf("authentication timed out")
 	}
 }
 
+// runTokenLogin validates an existing API key read from stdin and stores it
+func runTokenLogin() error {
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read token from stdin: %w", err)
+	}
+
+	apiKey := strings.TrimSpace(string(data))
+	if apiKey == "" {
+		return fmt.Errorf("no token provided on stdin")
+	}
+
+	info, err := whoami(apiKey)
+	if err != nil {
+		return fmt.Errorf("token validation failed: %w", err)
+	}
+
+	if err := storeCredentials(&authResult{apiKey: apiKey, workspaceID: info.WorkspaceID}); err != nil {
+		return err
+	}
+
+	if info.KeyName != "" {
+		fmt.Printf("  Key:       %s (%s)\n", info.KeyName, info.KeyID)
+	}
+	if len(info.Scopes) > 0 {
+		fmt.Printf("  Scopes:    %s\n", strings.Join(info.Scopes, ", "))
+	}
+	return nil
+}
+
+// keyInfo describes the API key making a request, as reported by whoami
+type keyInfo struct {
+	KeyID         string   `json:"keyId"`
+	KeyName       string   `json:"keyName"`
+	Scopes        []string `json:"scopes"`
+	ExpiresAt     string   `json:"expiresAt"`
+	WorkspaceID   string   `json:"workspaceId"`
+	WorkspaceName string   `json:"workspaceName"`
+}
+
+// errInvalidKey is returned by whoami when the API rejects the key
+var errInvalidKey = errors.New("API key is invalid or has been revoked")
+
+// whoami asks the API which key, workspace and scopes apiKey belongs to
+func whoami(apiKey string) (*keyInfo, error) {
+	req, _ := http.NewRequest("GET", GetAPIURL()+"/auth/whoami", nil)
+	req.Header.Set("Authorization", apiKey)
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, errInvalidKey
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var info keyInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if info.WorkspaceID == "" {
+		return nil, fmt.Errorf("API key is not associated with a workspace")
+	}
+
+	return &info, nil
+}
+
+// runDeviceLogin implements the device authorization flow: the user approves
+// a short code in a browser on any device while the CLI polls for the key
+func runDeviceLogin() error {
+	req, _ := http.NewRequest("POST", GetAPIURL()+"/cli/auth/device", nil)
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	var device struct {
+		DeviceCode              string `json:"deviceCode"`
+		UserCode                string `json:"userCode"`
+		VerificationURI         string `json:"verificationUri"`
+		VerificationURIComplete string `json:"verificationUriComplete"`
+		ExpiresIn               int    `json:"expiresIn"`
+		Interval                int    `json:"interval"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&device); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if device.VerificationURI == "" {
+		device.VerificationURI = webBaseURL() + "/cli/device"
+	}
+
+	fmt.Printf("To authenticate, visit:\n\n  %s\n\nand enter the code:\n\n  %s\n\n", device.VerificationURI, device.UserCode)
+	if device.VerificationURIComplete != "" {
+		fmt.Printf("Or open this link directly: %s\n\n", device.VerificationURIComplete)
+	}
+	fmt.Println("Waiting for approval...")
+
+	interval := time.Duration(device.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	expiresIn := time.Duration(device.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = 15 * time.Minute
+	}
+	deadline := time.Now().Add(expiresIn)
+
+	for time.Now().Before(deadline) {
+		time.Sleep(interval)
+
+		creds, status, err := pollDeviceToken(device.DeviceCode)
+		if err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+
+		switch status {
+		case "":
+			return storeCredentials(creds)
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += 5 * time.Second
+		case "access_denied":
+			return fmt.Errorf("authentication failed: request was denied")
+		case "expired_token":
+			return fmt.Errorf("authentication timed out")
+		default:
+			return fmt.Errorf("authentication failed: %s", status)
+		}
+	}
+
+	return fmt.Errorf("authentication timed out")
+}
+
+// pollDeviceToken checks whether a device code has been approved. It returns
+// the credentials once approved, or the pending/error status reported by the
+// API.
+func pollDeviceToken(deviceCode string) (*authResult, string, error) {
+	body, _ := json.Marshal(map[string]string{"deviceCode": deviceCode})
+
+	req, _ := http.NewRequest("POST", GetAPIURL()+"/cli/auth/device/token", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+
+	var result struct {
+		Key         string `json:"key"`
+		WorkspaceID string `json:"workspaceId"`
+		Error       string `json:"error"`
+	}
+	json.Unmarshal(respBody, &result)
+
+	if resp.StatusCode == http.StatusOK {
+		if result.Key == "" || result.WorkspaceID == "" {
+			return nil, "", fmt.Errorf("missing credentials in device token response")
+		}
+		return &authResult{apiKey: result.Key, workspaceID: result.WorkspaceID}, "", nil
+	}
+
+	if result.Error != "" {
+		return nil, result.Error, nil
+	}
+
+	return nil, "", parseAPIError(resp, respBody)
+}
+
+// storeCredentials saves a newly issued API key and its workspace, the same
+// way for every login flow
+func storeCredentials(creds *authResult) error {
+	// Store API key in the configured credential store
+	if credentialStore() == storeEnv {
+		// Don't print the secret, where it would end up in scrollback and logs
+		fmt.Printf("Credential store is env, so the API key %s was not saved.\n", redactKey(creds.apiKey))
+		fmt.Println("  Set HY_API_KEY to a key from your secret manager, or create one with 'hy keys create'")
+	} else if _, err := saveAPIKey(activeProfile(), creds.apiKey, credentialStore()); err != nil {
+		return fmt.Errorf("failed to store API key: %w", err)
+	}
+
+	// Store workspace ID in config
+	setProfileSetting("workspace_id", creds.workspaceID)
+	if err := saveConfig(); err != nil {
+		return err
+	}
+
+	fmt.Println("\n✓ Authenticated successfully")
+	if name := activeProfile(); name != defaultProfile {
+		fmt.Printf("  Profile:   %s\n", name)
+	}
+	fmt.Printf("  Workspace: %s\n", creds.workspaceID)
+	return nil
+}
+
+// newAuthCallbackHandler serves the /callback redirect from the browser.
+// Callbacks whose state doesn't match are rejected without ending the login,
+// so a stray request to the port can neither inject credentials nor abort it.
+func newAuthCallbackHandler(state string, resultCh chan<- authResult) http.Handler {
+	var once sync.Once
+	deliver := func(r authResult) {
+		once.Do(func() { resultCh <- r })
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/callback" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		query := r.URL.Query()
+		if subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `<html><body><h1>❌ Authentication failed</h1><p>Invalid or missing state. Start again with <code>hy auth login</code>.</p></body></html>`)
+			return
+		}
+
+		code := query.Get("code")
+		errorMsg := query.Get("error")
+
+		if errorMsg != "" {
+			deliver(authResult{err: errors.New(errorMsg)})
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprintf(w, `<html><body><h1>❌ Authentication failed</h1><p>%s</p><p>You can close this window.</p></body></html>`, html.EscapeString(errorMsg))
+			return
+		}
+
+		if code == "" {
+			deliver(authResult{err: fmt.Errorf("missing authorization code in callback")})
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprint(w, `<html><body><h1>❌ Authentication failed</h1><p>Missing authorization code.</p><p>You can close this window.</p></body></html>`)
+			return
+		}
+
+		deliver(authResult{code: code})
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<html><body><h1>✅ Authenticated!</h1><p>You can close this window and return to the terminal.</p><script>window.close()</script></body></html>`)
+	})
+}
+
+// exchangeAuthCode redeems a one-time login code for an API key. The verifier
+// proves this is the process that started the login.
+func exchangeAuthCode(code, verifier string) (*authResult, error) {
+	body, _ := json.Marshal(map[string]string{
+		"code":          code,
+		"code_verifier": verifier,
+	})
+
+	req, _ := http.NewRequest("POST", GetAPIURL()+"/cli/auth/token", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("code exchange failed: %w", newAPIError(resp))
+	}
+
+	var result struct {
+		Key         string `json:"key"`
+		WorkspaceID string `json:"workspaceId"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if result.Key == "" || result.WorkspaceID == "" {
+		return nil, fmt.Errorf("missing credentials in code exchange response")
+	}
+
+	return &authResult{apiKey: result.Key, workspaceID: result.WorkspaceID}, nil
+}
+
+// randomToken returns 32 random bytes encoded as unpadded base64url
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// codeChallenge derives the S256 PKCE challenge for a verifier
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// webBaseURL returns the web app URL, i.e. the API URL without its /api suffix
+func webBaseURL() string {
+	baseURL := strings.TrimSuffix(GetAPIURL(), "/api")
+	return strings.TrimSuffix(baseURL, "/")
+}
+
 type authResult struct {
+	code        string
 	apiKey      string
 	workspaceID string
 	err         error
@@ -164,63 +493,198 @@ func openBrowser(url string) error {
 
 var authLogoutCmd = &cobra.Command{
 	Use:   "logout",
-	Short: "Clear stored credentials",
+	Short: "Revoke the API key and clear stored credentials",
+	Long: `Revoke the stored API key on the server, then remove it from the
+keyring, the credentials file and the config file.
+
+Use --local-only to keep the key valid and only clear local credentials,
+e.g. when the key is shared with another machine.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Clear from keyring
-		keyring.Delete(serviceName, keyringUser)
+		localOnly, _ := cmd.Flags().GetBool("local-only")
+		profile := activeProfile()
+
+		// Only revoke stored keys; a key from HY_API_KEY is managed elsewhere
+		apiKey, _ := loadAPIKey(profile)
+		if apiKey == "" {
+			apiKey = viper.GetString(profileKey(profile, "api_key"))
+		}
+
+		if apiKey != "" && !localOnly {
+			if err := revokeOwnKey(apiKey); err != nil {
+				return fmt.Errorf("failed to revoke API key: %w (use --local-only to only clear local credentials)", err)
+			}
+		}
+
+		var errs []error
+
+		// Clear from keyring and credentials file
+		if err := deleteAPIKey(profile); err != nil {
+			errs = append(errs, err)
+		}
 
 		// Clear from config
-		viper.Set("api_key", "")
-		viper.Set("workspace_id", "")
-		viper.WriteConfig()
+		setProfileSetting("api_key", "")
+		setProfileSetting("workspace_id", "")
+		if err := saveConfig(); err != nil {
+			errs = append(errs, err)
+		}
+
+		if len(errs) > 0 {
+			return fmt.Errorf("failed to clear local credentials: %w", errors.Join(errs...))
+		}
 
 		fmt.Println("✓ Logged out")
+		if os.Getenv("HY_API_KEY") != "" {
+			fmt.Println("  HY_API_KEY is still set in your environment")
+		}
 		return nil
 	},
 }
 
+// revokeOwnKey looks up the ID of apiKey and revokes it. A key the server
+// already rejects needs no revoking.
+func revokeOwnKey(apiKey string) error {
+	info, err := whoami(apiKey)
+	if errors.Is(err, errInvalidKey) {
+		fmt.Println("API key was already invalid")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.KeyID == "" {
+		return fmt.Errorf("API did not return a key ID")
+	}
+
+	if err := revokeKey(apiKey, info.WorkspaceID, info.KeyID); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Revoked API key: %s\n", info.KeyID)
+	return nil
+}
+
 var authStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show current auth status",
+	Long: `Show the stored credential and verify it against the API.
+
+Reports whether the key is valid, its name, scopes and expiry, the
+workspace it belongs to and where the key was loaded from. Exits non-zero
+when no key is configured, or the key is invalid, revoked or cannot be
+verified.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		apiKey := GetAPIKey()
-		workspaceID := GetWorkspaceID()
+		apiKey, source := apiKeySource()
 
 		if apiKey == "" {
-			fmt.Println("Not authenticated")
-			fmt.Println("Run 'hy auth login' to authenticate")
-			return nil
+			return errNotAuthenticated
 		}
 
-		// Show redacted key
-		redacted := apiKey[:12] + "..." + apiKey[len(apiKey)-4:]
-		fmt.Printf("API Key: %s\n", redacted)
-		fmt.Printf("Workspace: %s\n", workspaceID)
-		fmt.Printf("API URL: %s\n", GetAPIURL())
+		if name := activeProfile(); name != defaultProfile {
+			fmt.Printf("Profile:   %s\n", name)
+		}
+		fmt.Printf("API Key:   %s\n", redactKey(apiKey))
+		fmt.Printf("Source:    %s\n", source)
+		fmt.Printf("API URL:   %s\n", GetAPIURL())
+
+		info, err := whoami(apiKey)
+		if errors.Is(err, errInvalidKey) {
+			fmt.Println("Status:    ✗ invalid")
+			if source != "env" {
+				fmt.Println("Run 'hy auth login' to authenticate again")
+			}
+			return err
+		}
+		if err != nil {
+			// The key may be fine; the API couldn't say
+			fmt.Printf("Status:    ? could not verify (%v)\n", err)
+			return fmt.Errorf("could not verify API key: %w", err)
+		}
+
+		fmt.Println("Status:    ✓ valid")
+		if info.KeyName != "" || info.KeyID != "" {
+			fmt.Printf("Key:       %s (%s)\n", valueOrDash(info.KeyName), valueOrDash(info.KeyID))
+		}
+		if len(info.Scopes) > 0 {
+			fmt.Printf("Scopes:    %s\n", strings.Join(info.Scopes, ", "))
+		}
+		fmt.Printf("Expires:   %s\n", formatExpiry(info.ExpiresAt))
+		if info.WorkspaceName != "" {
+			fmt.Printf("Workspace: %s (%s)\n", info.WorkspaceName, info.WorkspaceID)
+		} else {
+			fmt.Printf("Workspace: %s\n", info.WorkspaceID)
+		}
+
+		if workspaceID := GetWorkspaceID(); workspaceID != "" && workspaceID != info.WorkspaceID {
+			fmt.Printf("Warning: configured workspace %s does not match the key's workspace\n", workspaceID)
+		}
 
 		return nil
 	},
 }
 
+// redactKey shows enough of a key to identify it without revealing it.
+// Short keys are masked entirely.
+func redactKey(key string) string {
+	if len(key) < 20 {
+		return strings.Repeat("*", len(key))
+	}
+	return key[:12] + "..." + key[len(key)-4:]
+}
+
+// formatExpiry describes an RFC 3339 expiry time relative to now
+func formatExpiry(expiresAt string) string {
+	if expiresAt == "" {
+		return "never"
+	}
+	t, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return expiresAt
+	}
+	days := int(time.Until(t).Hours() / 24)
+	switch {
+	case time.Now().After(t):
+		return t.Format("2006-01-02") + " (expired)"
+	case days == 0:
+		return t.Format("2006-01-02") + " (today)"
+	default:
+		return fmt.Sprintf("%s (in %d days)", t.Format("2006-01-02"), days)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(authCmd)
 	authCmd.AddCommand(authLoginCmd)
 	authCmd.AddCommand(authLogoutCmd)
 	authCmd.AddCommand(authStatusCmd)
+
+	authLoginCmd.Flags().Bool("device", false, "Log in with a device code instead of a local browser")
+	authLoginCmd.Flags().Bool("with-token", false, "Read an existing API key from stdin")
+	authLogoutCmd.Flags().Bool("local-only", false, "Clear local credentials without revoking the key")
 }
 
-// GetAPIKey returns the stored API key
+// GetAPIKey returns the stored API key for the active profile
 func GetAPIKey() string {
+	key, _ := apiKeySource()
+	return key
+}
+
+// apiKeySource returns the API key for the active profile and where it came
+// from: env, keyring, file or config
+func apiKeySource() (string, string) {
 	// Check environment first
 	if key := os.Getenv("HY_API_KEY"); key != "" {
-		return key
+		return key, "env"
 	}
 
-	// Check keyring
-	if key, err := keyring.Get(serviceName, keyringUser); err == nil {
-		return key
+	// Check keyring or encrypted file
+	if key, source := loadAPIKey(activeProfile()); key != "" {
+		return key, source
 	}
 
-	// Fallback to config
-	return viper.GetString("api_key")
+	// Fallback to plaintext config (older versions; see 'hy auth migrate')
+	if key := profileSetting("api_key"); key != "" {
+		return key, "config"
+	}
+	return "", ""
 }
@@ -0,0 +1,447 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/zalando/go-keyring"
+)
+
+func TestAuthCallbackRejectsMismatchedState(t *testing.T) {
+	resultCh := make(chan authResult, 1)
+	handler := newAuthCallbackHandler("expected_state", resultCh)
+
+	for _, target := range []string{
+		"/callback?code=abc&state=wrong_state",
+		"/callback?code=abc",
+		"/callback?key=sk_live_injected&workspace=ws_evil",
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", target, nil))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", target, rec.Code)
+		}
+	}
+
+	select {
+	case r := <-resultCh:
+		t.Fatalf("Mismatched callbacks should not complete login, got %+v", r)
+	default:
+	}
+}
+
+func TestAuthCallbackAcceptsMatchingState(t *testing.T) {
+	resultCh := make(chan authResult, 1)
+	handler := newAuthCallbackHandler("expected_state", resultCh)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/callback?code=code_123&state=expected_state", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+
+	r := <-resultCh
+	if r.err != nil || r.code != "code_123" {
+		t.Errorf("Expected code_123, got %+v", r)
+	}
+}
+
+func TestExchangeAuthCode(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var receivedBody map[string]string
+
+	tc.Server.Handle("POST", "/cli/auth/token", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &receivedBody)
+
+		if receivedBody["code_verifier"] != "verifier_123" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid code verifier"})
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{
+			"key":         "sk_live_exchanged",
+			"workspaceId": "ws_test123",
+		})
+	})
+
+	creds, err := exchangeAuthCode("code_123", "verifier_123")
+	if err != nil {
+		t.Fatalf("Exchange failed: %v", err)
+	}
+
+	if receivedBody["code"] != "code_123" {
+		t.Errorf("Expected code in request body, got %v", receivedBody["code"])
+	}
+	if creds.apiKey != "sk_live_exchanged" || creds.workspaceID != "ws_test123" {
+		t.Errorf("Unexpected credentials: %+v", creds)
+	}
+
+	if _, err := exchangeAuthCode("code_123", "wrong_verifier"); err == nil {
+		t.Error("Expected error for wrong verifier")
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// RFC 7636 appendix B test vector
+	got := codeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+	if got != "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM" {
+		t.Errorf("Unexpected challenge: %s", got)
+	}
+}
+
+func TestAuthLoginDevice(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("POST", "/cli/auth/device", http.StatusOK, map[string]interface{}{
+		"deviceCode":      "dev_123",
+		"userCode":        "WDJB-MJHT",
+		"verificationUri": "https://studio.hypewell.ai/cli/device",
+		"expiresIn":       60,
+		"interval":        1,
+	})
+
+	polls := 0
+	tc.Server.Handle("POST", "/cli/auth/device/token", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.Header().Set("Content-Type", "application/json")
+		if polls == 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "authorization_pending"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"key":         "sk_live_device",
+			"workspaceId": "ws_device",
+		})
+	})
+
+	output, err := ExecuteCommand("auth", "login", "--device")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "WDJB-MJHT")
+	AssertContains(t, output, "Authenticated successfully")
+
+	if key, _ := keyring.Get(serviceName, keyringUser); key != "sk_live_device" {
+		t.Errorf("Expected key stored in keyring, got %q", key)
+	}
+	if viper.GetString("workspace_id") != "ws_device" {
+		t.Errorf("Expected workspace stored, got %q", viper.GetString("workspace_id"))
+	}
+}
+
+func TestAuthLoginDeviceDenied(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("POST", "/cli/auth/device", http.StatusOK, map[string]interface{}{
+		"deviceCode": "dev_123",
+		"userCode":   "WDJB-MJHT",
+		"interval":   1,
+	})
+	tc.Server.HandleJSON("POST", "/cli/auth/device/token", http.StatusBadRequest, map[string]string{
+		"error": "access_denied",
+	})
+
+	_, err := ExecuteCommand("auth", "login", "--device")
+	if err == nil {
+		t.Fatal("Expected error when request is denied")
+	}
+	if !strings.Contains(err.Error(), "denied") {
+		t.Errorf("Error should mention denial: %v", err)
+	}
+}
+
+func TestAuthLoginWithToken(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var receivedAuth string
+
+	tc.Server.Handle("GET", "/auth/whoami", func(w http.ResponseWriter, r *http.Request) {
+		receivedAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keyId":       "key_ci123",
+			"keyName":     "CI Key",
+			"scopes":      []string{"productions:read", "productions:write"},
+			"workspaceId": "ws_ci",
+		})
+	})
+
+	var err error
+	output := CaptureOutput(func() {
+		_, err = ExecuteCommandWithStdin("sk_live_from_stdin\n", "auth", "login", "--with-token")
+	})
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if receivedAuth != "sk_live_from_stdin" {
+		t.Errorf("Expected token from stdin to be validated, got %q", receivedAuth)
+	}
+	if key, _ := keyring.Get(serviceName, keyringUser); key != "sk_live_from_stdin" {
+		t.Errorf("Expected key stored in keyring, got %q", key)
+	}
+	if viper.GetString("workspace_id") != "ws_ci" {
+		t.Errorf("Expected workspace stored, got %q", viper.GetString("workspace_id"))
+	}
+
+	AssertContains(t, output, "Authenticated successfully")
+	AssertContains(t, output, "productions:write")
+}
+
+func TestAuthLoginEnvStoreDoesNotPrintKey(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/auth/whoami", http.StatusOK, map[string]interface{}{
+		"keyId":       "key_ci123",
+		"workspaceId": "ws_ci",
+	})
+
+	var err error
+	output := CaptureOutput(func() {
+		_, err = ExecuteCommandWithStdin("sk_live_secret_from_stdin_1234\n", "--credential-store", "env", "auth", "login", "--with-token")
+	})
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if strings.Contains(output, "sk_live_secret_from_stdin_1234") {
+		t.Errorf("Output must not contain the API key:\n%s", output)
+	}
+	AssertContains(t, output, "was not saved")
+	AssertContains(t, output, "HY_API_KEY")
+}
+
+func TestAuthLoginWithTokenInvalid(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/auth/whoami", http.StatusUnauthorized, map[string]string{
+		"error": "Invalid API key",
+	})
+
+	_, err := ExecuteCommandWithStdin("sk_live_bogus\n", "auth", "login", "--with-token")
+	if err == nil {
+		t.Fatal("Expected error for invalid token")
+	}
+
+	if key, _ := keyring.Get(serviceName, keyringUser); key != "" {
+		t.Errorf("Invalid key should not be stored, got %q", key)
+	}
+}
+
+func TestAuthStatusValid(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/auth/whoami", http.StatusOK, map[string]interface{}{
+		"keyId":         "key_123",
+		"keyName":       "laptop",
+		"scopes":        []string{"productions:read", "productions:write"},
+		"expiresAt":     "2999-01-01T00:00:00Z",
+		"workspaceId":   "ws_test123",
+		"workspaceName": "Acme",
+	})
+
+	output, err := ExecuteCommand("auth", "status")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "✓ valid")
+	AssertContains(t, output, "laptop (key_123)")
+	AssertContains(t, output, "productions:read, productions:write")
+	AssertContains(t, output, "2999-01-01")
+	AssertContains(t, output, "Acme (ws_test123)")
+	AssertContains(t, output, "Source:    config")
+	AssertNotContains(t, output, "sk_live_test123")
+}
+
+func TestAuthStatusInvalid(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/auth/whoami", http.StatusUnauthorized, map[string]string{
+		"error": "revoked",
+	})
+
+	output, err := ExecuteCommand("auth", "status")
+	if err == nil {
+		t.Fatal("Expected error for revoked key")
+	}
+
+	AssertContains(t, output, "✗ invalid")
+	AssertContains(t, err.Error(), "invalid or has been revoked")
+}
+
+func TestAuthStatusNotAuthenticated(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	// ExecuteCommand sets a key for the default profile only
+	viper.Set("profiles.staging.api_url", tc.Server.URL)
+
+	_, err := ExecuteCommand("--profile", "staging", "auth", "status")
+	if !errors.Is(err, errNotAuthenticated) || ExitCode(err) != ExitAuth {
+		t.Errorf("Expected errNotAuthenticated, got %v", err)
+	}
+}
+
+func TestAuthStatusCouldNotVerify(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/auth/whoami", http.StatusInternalServerError, map[string]string{
+		"error": "database unavailable",
+	})
+
+	output, err := ExecuteCommand("auth", "status", "--retries", "0")
+	if err == nil {
+		t.Fatal("Expected error when the key can't be verified")
+	}
+
+	if strings.Contains(output, "invalid") {
+		t.Errorf("A server error must not report the key as invalid:\n%s", output)
+	}
+	AssertContains(t, output, "could not verify")
+	AssertContains(t, err.Error(), "database unavailable")
+}
+
+func TestAuthStatusEnvSource(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	t.Setenv("HY_API_KEY", "sk_live_0123456789abcdef")
+	tc.Server.HandleJSON("GET", "/auth/whoami", http.StatusOK, map[string]interface{}{
+		"keyId":       "key_123",
+		"workspaceId": "ws_test123",
+	})
+
+	output, err := ExecuteCommand("auth", "status")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "Source:    env")
+	AssertContains(t, output, "sk_live_0123...cdef")
+	AssertContains(t, output, "Expires:   never")
+}
+
+func TestRedactKey(t *testing.T) {
+	tests := map[string]string{
+		"":                         "",
+		"abc":                      "***",
+		"sk_live_short":            "*************",
+		"sk_live_0123456789abcdef": "sk_live_0123...cdef",
+	}
+
+	for key, want := range tests {
+		if got := redactKey(key); got != want {
+			t.Errorf("redactKey(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestAuthLogoutRevokesKey(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	revoked := false
+	tc.Server.HandleJSON("GET", "/auth/whoami", http.StatusOK, map[string]interface{}{
+		"keyId":       "key_123",
+		"workspaceId": "ws_test123",
+	})
+	tc.Server.Handle("DELETE", "/workspaces/ws_test123/keys/key_123", func(w http.ResponseWriter, r *http.Request) {
+		revoked = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	output, err := ExecuteCommand("auth", "logout")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if !revoked {
+		t.Error("Expected key to be revoked")
+	}
+	AssertContains(t, output, "Revoked API key: key_123")
+	AssertContains(t, output, "Logged out")
+	if viper.GetString("api_key") != "" {
+		t.Error("Expected api_key to be cleared from config")
+	}
+}
+
+func TestAuthLogoutLocalOnly(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.Handle("GET", "/auth/whoami", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("--local-only should not contact the API")
+	})
+
+	output, err := ExecuteCommand("auth", "logout", "--local-only")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "Logged out")
+	AssertNotContains(t, output, "Revoked")
+}
+
+func TestAuthLogoutRevokeFailure(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/auth/whoami", http.StatusOK, map[string]interface{}{
+		"keyId":       "key_123",
+		"workspaceId": "ws_test123",
+	})
+	tc.Server.HandleJSON("DELETE", "/workspaces/ws_test123/keys/key_123", http.StatusInternalServerError, map[string]string{
+		"error": "boom",
+	})
+
+	_, err := ExecuteCommand("auth", "logout")
+	if err == nil {
+		t.Fatal("Expected error when revocation fails")
+	}
+
+	AssertContains(t, err.Error(), "--local-only")
+	if viper.GetString("api_key") == "" {
+		t.Error("Credentials should be kept when revocation fails")
+	}
+}
+
+func TestAuthLogoutAlreadyRevoked(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/auth/whoami", http.StatusUnauthorized, map[string]string{
+		"error": "revoked",
+	})
+
+	output, err := ExecuteCommand("auth", "logout")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "already invalid")
+	AssertContains(t, output, "Logged out")
+}
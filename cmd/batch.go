@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// batchResult is the outcome of one item in a batch operation
+type batchResult struct {
+	ID     string
+	Detail string
+	Err    error
+}
+
+func runBatchBuild(cmd *cobra.Command, args []string, apiKey, workspaceID string) error {
+	all, _ := cmd.Flags().GetBool("all")
+	status, _ := cmd.Flags().GetString("status")
+	yes, _ := cmd.Flags().GetBool("yes")
+	force, _ := cmd.Flags().GetBool("force")
+	validateOnly, _ := cmd.Flags().GetBool("validate-only")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+	var ids []string
+	var err error
+
+	switch {
+	case validateOnly:
+		return fmt.Errorf("--validate-only works with a single production ID")
+	case all && len(args) > 0:
+		return fmt.Errorf("--all cannot be combined with production IDs")
+	case all:
+		if status == "" {
+			return fmt.Errorf("--all requires --status (e.g. --status failed)")
+		}
+		ids, err = listProductionIDs(apiKey, workspaceID, status)
+	case len(args) == 0:
+		return fmt.Errorf("specify production IDs, \"-\" to read from stdin, or --status with --all")
+	default:
+		ids, err = readIDs(args)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		fmt.Println("No productions to build")
+		return nil
+	}
+
+	if !yes {
+		if readsStdin(args) {
+			return fmt.Errorf("reading IDs from stdin requires --yes (cannot prompt for confirmation)")
+		}
+		fmt.Printf("Build %d productions? [y/N] ", len(ids))
+		if !confirmPrompt() {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	results := runBatch(ids, concurrency, func(id string) (string, error) {
+		result, err := triggerBuild(apiKey, workspaceID, id, "build")
+
+		// With --force, cancel the in-flight build and try again
+		var apiErr *APIError
+		if force && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+			if err := cancelBuild(apiKey, workspaceID, id); err != nil {
+				return "", err
+			}
+			result, err = triggerBuildAfterCancel(apiKey, workspaceID, id)
+		}
+		if err != nil {
+			return "", err
+		}
+		return result.BuildID, nil
+	})
+
+	return printBatchResults(results, "BUILD ID")
+}
+
+func runBatchDelete(cmd *cobra.Command, args []string, apiKey, workspaceID string) error {
+	force, _ := cmd.Flags().GetBool("force")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+	ids, err := readIDs(args)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		fmt.Println("No productions to delete")
+		return nil
+	}
+
+	if !force {
+		if readsStdin(args) {
+			return fmt.Errorf("reading IDs from stdin requires --force (cannot prompt for confirmation)")
+		}
+		fmt.Printf("Delete %d productions? This can be undone within 30 days. [y/N] ", len(ids))
+		if !confirmPrompt() {
+			fmt.Println("Cancelled")
+			return nil
+		}
+	}
+
+	results := runBatch(ids, concurrency, func(id string) (string, error) {
+		return "", deleteProduction(apiKey, workspaceID, id)
+	})
+
+	return printBatchResults(results, "")
+}
+
+// runBatch applies fn to every ID with at most concurrency calls in flight.
+// Results are returned in input order.
+func runBatch(ids []string, concurrency int, fn func(id string) (string, error)) []batchResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]batchResult, len(ids))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			detail, err := fn(id)
+			results[i] = batchResult{ID: id, Detail: detail, Err: err}
+		}(i, id)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// printBatchResults prints a per-item result table and returns an error if
+// any item failed. detailHeader names the optional detail column.
+func printBatchResults(results []batchResult, detailHeader string) error {
+	failed := 0
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if detailHeader != "" {
+		fmt.Fprintf(w, "ID\tRESULT\t%s\tERROR\n", detailHeader)
+	} else {
+		fmt.Fprintln(w, "ID\tRESULT\tERROR")
+	}
+	for _, r := range results {
+		result, errMsg := "✓ ok", "-"
+		if r.Err != nil {
+			failed++
+			result, errMsg = "✗ failed", r.Err.Error()
+		}
+		if detailHeader != "" {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.ID, result, valueOrDash(r.Detail), errMsg)
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", r.ID, result, errMsg)
+		}
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d succeeded, %d failed\n", len(results)-failed, failed)
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d operations failed", failed, len(results))
+	}
+	return nil
+}
+
+// readIDs returns IDs from args, expanding "-" to whitespace-separated IDs
+// read from stdin. Duplicates are dropped.
+func readIDs(args []string) ([]string, error) {
+	var ids []string
+	seen := make(map[string]bool)
+
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, arg := range args {
+		if arg != "-" {
+			add(arg)
+			continue
+		}
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Split(bufio.ScanWords)
+		for scanner.Scan() {
+			add(scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read IDs from stdin: %w", err)
+		}
+	}
+
+	return ids, nil
+}
+
+func readsStdin(args []string) bool {
+	for _, arg := range args {
+		if arg == "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// listProductionIDs returns the IDs of all productions with the given status,
+// following pagination cursors.
+func listProductionIDs(apiKey, workspaceID, status string) ([]string, error) {
+	var ids []string
+	cursor := ""
+	seen := make(map[string]bool)
+
+	for {
+		listURL := fmt.Sprintf("%s/workspaces/%s/productions?limit=100&status=%s", GetAPIURL(), workspaceID, url.QueryEscape(status))
+		if cursor != "" {
+			listURL += "&cursor=" + url.QueryEscape(cursor)
+		}
+
+		req, _ := http.NewRequest("GET", listURL, nil)
+		req.Header.Set("Authorization", apiKey)
+
+		resp, err := doRequest(req)
+		if err != nil {
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, parseAPIError(resp, body)
+		}
+
+		var result struct {
+			Productions []struct {
+				ID string `json:"id"`
+			} `json:"productions"`
+			NextCursor string `json:"nextCursor"`
+			HasMore    bool   `json:"hasMore"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		for _, p := range result.Productions {
+			ids = append(ids, p.ID)
+		}
+
+		if !result.HasMore || result.NextCursor == "" {
+			return ids, nil
+		}
+		// A cursor seen before would page forever
+		if seen[result.NextCursor] {
+			return nil, fmt.Errorf("the API returned the same page cursor twice; the production list would be incomplete")
+		}
+		seen[result.NextCursor] = true
+		cursor = result.NextCursor
+	}
+}
+
+// confirmPrompt reads a y/yes answer from stdin
+func confirmPrompt() bool {
+	var confirm string
+	fmt.Scanln(&confirm)
+	confirm = strings.ToLower(confirm)
+	return confirm == "y" || confirm == "yes"
+}
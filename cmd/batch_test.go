@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestProductionsBuildAllByStatus(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var requestedURL string
+
+	tc.Server.Handle("GET", "/workspaces/ws_test123/productions", func(w http.ResponseWriter, r *http.Request) {
+		requestedURL = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ProductionsListResponse{
+			Productions: []ProductionResponse{
+				{ID: "prod_a", Status: "failed"},
+				{ID: "prod_b", Status: "failed"},
+			},
+		})
+	})
+
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/productions/prod_a/build", http.StatusAccepted, map[string]interface{}{
+		"id":      "prod_a",
+		"buildId": "build_a1",
+	})
+
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/productions/prod_b/build", http.StatusConflict, map[string]interface{}{
+		"error": "Build already in progress",
+	})
+
+	output, err := ExecuteCommand("productions", "build", "--status", "failed", "--all", "--yes")
+	if err == nil {
+		t.Fatal("Expected error when one build fails")
+	}
+
+	if !strings.Contains(requestedURL, "status=failed") {
+		t.Errorf("Expected status filter in URL, got: %s", requestedURL)
+	}
+
+	AssertContains(t, output, "build_a1")
+	AssertContains(t, output, "already in progress")
+	AssertContains(t, output, "1 succeeded, 1 failed")
+}
+
+func TestProductionsBuildAllRepeatedCursor(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	pages := 0
+	tc.Server.Handle("GET", "/workspaces/ws_test123/productions", func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ProductionsListResponse{
+			Productions: []ProductionResponse{{ID: "prod_a", Status: "failed"}},
+			NextCursor:  "same",
+			HasMore:     true,
+		})
+	})
+
+	_, err := ExecuteCommand("productions", "build", "--status", "failed", "--all", "--yes")
+	if err == nil {
+		t.Fatal("Expected error when the cursor repeats")
+	}
+	if pages != 2 {
+		t.Errorf("Expected to stop after 2 pages, fetched %d", pages)
+	}
+}
+
+func TestProductionsBuildAllRequiresStatus(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	_, err := ExecuteCommand("productions", "build", "--all", "--yes")
+	if err == nil {
+		t.Fatal("Expected error for --all without --status")
+	}
+}
+
+func TestProductionsDeleteMany(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var mu sync.Mutex
+	deleted := map[string]bool{}
+
+	for _, id := range []string{"prod_a", "prod_b", "prod_c"} {
+		id := id
+		tc.Server.Handle("DELETE", "/workspaces/ws_test123/productions/"+id, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			deleted[id] = true
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{"id": id, "deleted": true})
+		})
+	}
+
+	output, err := ExecuteCommand("productions", "delete", "prod_a", "prod_b", "prod_c", "--force")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if len(deleted) != 3 {
+		t.Errorf("Expected 3 deletes, got %d", len(deleted))
+	}
+
+	AssertContains(t, output, "3 succeeded, 0 failed")
+}
+
+func TestProductionsDeleteFromStdin(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("DELETE", "/workspaces/ws_test123/productions/prod_a", http.StatusOK, map[string]interface{}{"deleted": true})
+	tc.Server.HandleJSON("DELETE", "/workspaces/ws_test123/productions/prod_b", http.StatusNotFound, map[string]string{"error": "Production not found"})
+
+	var output string
+	var err error
+	output = CaptureOutput(func() {
+		_, err = ExecuteCommandWithStdin("prod_a\nprod_b\n", "productions", "delete", "-", "--force")
+	})
+
+	if err == nil {
+		t.Fatal("Expected error when one delete fails")
+	}
+
+	AssertContains(t, output, "prod_a")
+	AssertContains(t, output, "Production not found")
+	AssertContains(t, output, "1 succeeded, 1 failed")
+}
+
+func TestProductionsDeleteFromStdinRequiresForce(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	_, err := ExecuteCommandWithStdin("prod_a\n", "productions", "delete", "-")
+	if err == nil {
+		t.Fatal("Expected error when reading stdin without --force")
+	}
+}
+
+func TestRunBatchBoundsConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	entered := make(chan struct{}, 8)
+	release := make(chan struct{})
+
+	ids := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	done := make(chan []batchResult)
+	go func() {
+		done <- runBatch(ids, 2, func(id string) (string, error) {
+			mu.Lock()
+			inFlight++
+			if inFlight > peak {
+				peak = inFlight
+			}
+			mu.Unlock()
+
+			// Hold the call until the test has seen the limit reached
+			entered <- struct{}{}
+			<-release
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			return id, nil
+		})
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-entered:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Timed out waiting for concurrent calls")
+		}
+	}
+	select {
+	case <-entered:
+		t.Error("A third call started while two were in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+
+	results := <-done
+
+	if peak != 2 {
+		t.Errorf("Expected 2 concurrent calls, got %d", peak)
+	}
+	for i, r := range results {
+		if r.ID != ids[i] {
+			t.Errorf("Results out of order: got %s at %d", r.ID, i)
+		}
+	}
+}
+
+func TestProductionsBuildManyForce(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var mu sync.Mutex
+	cancelled := false
+	builds := 0
+	tc.Server.Handle("POST", "/workspaces/ws_test123/productions/prod_a/build", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		builds++
+		w.Header().Set("Content-Type", "application/json")
+		if !cancelled {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Build already in progress"})
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]string{"id": "prod_a", "buildId": "build_a2"})
+	})
+	tc.Server.Handle("POST", "/workspaces/ws_test123/productions/prod_a/build/cancel", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		cancelled = true
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"id": "prod_a"})
+	})
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/productions/prod_b/build", http.StatusAccepted, map[string]string{
+		"id": "prod_b", "buildId": "build_b1",
+	})
+
+	output, err := ExecuteCommand("productions", "build", "prod_a", "prod_b", "--force", "--yes")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if !cancelled || builds != 2 {
+		t.Errorf("Expected the in-flight build to be cancelled and retried, got cancelled=%v builds=%d", cancelled, builds)
+	}
+	AssertContains(t, output, "build_a2")
+	AssertContains(t, output, "2 succeeded, 0 failed")
+}
+
+func TestProductionsBuildManyRejectsValidateOnly(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	_, err := ExecuteCommand("productions", "build", "prod_a", "prod_b", "--validate-only", "--yes")
+	if err == nil {
+		t.Fatal("Expected error for --validate-only with several IDs")
+	}
+	AssertContains(t, err.Error(), "--validate-only")
+}
+
+func TestProductionsBuildStatusRequiresAll(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	_, err := ExecuteCommand("productions", "build", "prod_a", "--status", "failed")
+	if err == nil {
+		t.Fatal("Expected error for --status without --all")
+	}
+	AssertContains(t, err.Error(), "--status requires --all")
+}
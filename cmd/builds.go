@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// buildRecord is the build state returned by the build and builds endpoints
+type buildRecord struct {
+	ID              string  `json:"id"`
+	Status          string  `json:"status"`
+	BuildID         *string `json:"buildId"`
+	BuildLogURL     *string `json:"buildLogUrl"`
+	BuildFinishedAt *string `json:"buildFinishedAt"`
+	OutputURL       *string `json:"outputUrl"`
+}
+
+var productionsBuildsCmd = &cobra.Command{
+	Use:   "builds [production-id]",
+	Short: "List past builds for a production",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		productionID := args[0]
+
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		workspaceID := GetWorkspaceID()
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		url := fmt.Sprintf("%s/workspaces/%s/productions/%s/builds?limit=%d", GetAPIURL(), workspaceID, productionID, limit)
+
+		req, _ := http.NewRequest("GET", url, nil)
+		req.Header.Set("Authorization", apiKey)
+
+		resp, err := doRequest(req)
+		if err != nil {
+			return fmt.Errorf("request failed: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return newAPIError(resp)
+		}
+
+		var result struct {
+			Builds []struct {
+				ID          string `json:"id"`
+				Status      string `json:"status"`
+				StartedAt   string `json:"startedAt"`
+				FinishedAt  string `json:"finishedAt"`
+				TriggeredBy string `json:"triggeredBy"`
+				OutputURL   string `json:"outputUrl"`
+			} `json:"builds"`
+			HasMore bool `json:"hasMore"`
+		}
+
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		if len(result.Builds) == 0 {
+			fmt.Println("No builds found")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "BUILD ID\tSTATUS\tSTARTED\tFINISHED\tDURATION\tTRIGGERED BY\tOUTPUT")
+		for _, b := range result.Builds {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				b.ID,
+				b.Status,
+				valueOrDash(b.StartedAt),
+				valueOrDash(b.FinishedAt),
+				buildDuration(b.StartedAt, b.FinishedAt),
+				valueOrDash(b.TriggeredBy),
+				valueOrDash(b.OutputURL),
+			)
+		}
+		w.Flush()
+
+		if result.HasMore {
+			fmt.Printf("\n(more results available, use --limit)\n")
+		}
+
+		return nil
+	},
+}
+
+var productionsLogsCmd = &cobra.Command{
+	Use:   "logs [production-id]",
+	Short: "Print build logs for a production",
+	Long: `Print build logs for the latest build of a production.
+
+Examples:
+  hy productions logs prod_xxx
+  hy productions logs prod_xxx --build build_xxx`,
+	Args: usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		productionID := args[0]
+
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		workspaceID := GetWorkspaceID()
+		buildID, _ := cmd.Flags().GetString("build")
+
+		url := buildEndpoint(workspaceID, productionID, buildID) + "/logs"
+
+		req, _ := http.NewRequest("GET", url, nil)
+		req.Header.Set("Authorization", apiKey)
+
+		resp, err := doRequest(req)
+		if err != nil {
+			return fmt.Errorf("request failed: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return newAPIError(resp)
+		}
+
+		if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
+			return fmt.Errorf("failed to read logs: %w", err)
+		}
+
+		return nil
+	},
+}
+
+var productionsDownloadCmd = &cobra.Command{
+	Use:   "download [production-id]",
+	Short: "Download the rendered output of a build",
+	Long: `Download the rendered output of the latest build of a production.
+
+Examples:
+  hy productions download prod_xxx
+  hy productions download prod_xxx -o final.mp4
+  hy productions download prod_xxx --build build_xxx`,
+	Args: usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		productionID := args[0]
+
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		buildID, _ := cmd.Flags().GetString("build")
+		outPath, _ := cmd.Flags().GetString("output")
+
+		build, err := fetchBuild(apiKey, GetWorkspaceID(), productionID, buildID)
+		if err != nil {
+			return err
+		}
+
+		if build.OutputURL == nil || *build.OutputURL == "" {
+			return fmt.Errorf("build has no output (status: %s)", build.Status)
+		}
+
+		if outPath == "" {
+			outPath = defaultOutputPath(productionID, build)
+		}
+
+		// Output URLs are signed, so no auth header
+		req, _ := http.NewRequest("GET", *build.OutputURL, nil)
+		resp, err := doRequest(req)
+		if err != nil {
+			return fmt.Errorf("download failed: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("download failed: %w", newAPIError(resp))
+		}
+
+		file, err := os.Create(outPath)
+		if err != nil {
+			return fmt.Errorf("cannot create file: %w", err)
+		}
+
+		// Don't leave a partial file behind
+		n, err := io.Copy(file, resp.Body)
+		if err != nil {
+			file.Close()
+			os.Remove(outPath)
+			return fmt.Errorf("download failed: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			os.Remove(outPath)
+			return fmt.Errorf("failed to write %s: %w", outPath, err)
+		}
+
+		fmt.Printf("✓ Downloaded %s (%s)\n", outPath, formatBytes(n))
+		return nil
+	},
+}
+
+// buildEndpoint returns the URL for the latest build of a production, or for
+// a specific historical build when buildID is set
+func buildEndpoint(workspaceID, productionID, buildID string) string {
+	if buildID != "" {
+		return fmt.Sprintf("%s/workspaces/%s/productions/%s/builds/%s", GetAPIURL(), workspaceID, productionID, buildID)
+	}
+	return fmt.Sprintf("%s/workspaces/%s/productions/%s/build", GetAPIURL(), workspaceID, productionID)
+}
+
+// fetchBuild gets the latest build, or a specific one when buildID is set
+func fetchBuild(apiKey, workspaceID, productionID, buildID string) (*buildRecord, error) {
+	req, _ := http.NewRequest("GET", buildEndpoint(workspaceID, productionID, buildID), nil)
+	req.Header.Set("Authorization", apiKey)
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result buildRecord
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// buildDuration formats the time between two RFC 3339 timestamps. Builds that
+// haven't finished are measured up to now.
+func buildDuration(startedAt, finishedAt string) string {
+	start, err := time.Parse(time.RFC3339, startedAt)
+	if err != nil {
+		return "-"
+	}
+	end := time.Now()
+	if finishedAt != "" {
+		if end, err = time.Parse(time.RFC3339, finishedAt); err != nil {
+			return "-"
+		}
+	}
+	return end.Sub(start).Round(time.Second).String()
+}
+
+// defaultOutputPath names the download after the build ID, keeping the
+// extension of the signed output URL (falling back to .mp4)
+func defaultOutputPath(productionID string, build *buildRecord) string {
+	name := productionID
+	if build.BuildID != nil && *build.BuildID != "" {
+		name = *build.BuildID
+	}
+
+	ext := ".mp4"
+	if u, err := neturl.Parse(*build.OutputURL); err == nil && path.Ext(u.Path) != "" {
+		ext = path.Ext(u.Path)
+	}
+
+	return name + ext
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func init() {
+	productionsCmd.AddCommand(productionsBuildsCmd)
+	productionsCmd.AddCommand(productionsLogsCmd)
+	productionsCmd.AddCommand(productionsDownloadCmd)
+
+	// Builds flags
+	productionsBuildsCmd.Flags().Int("limit", 20, "Maximum number of results")
+
+	// Logs flags
+	productionsLogsCmd.Flags().String("build", "", "Show logs for a specific historical build")
+
+	// Download flags
+	productionsDownloadCmd.Flags().String("build", "", "Download output of a specific historical build")
+	productionsDownloadCmd.Flags().StringP("output", "o", "", "Output file path (default: <build-id>.<ext>)")
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProductionsBuilds(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123/builds", http.StatusOK, map[string]interface{}{
+		"builds": []map[string]interface{}{
+			{
+				"id":          "build_new456",
+				"status":      "review",
+				"startedAt":   "2026-02-06T12:00:00Z",
+				"finishedAt":  "2026-02-06T12:04:30Z",
+				"triggeredBy": "alice@example.com",
+				"outputUrl":   "https://storage.googleapis.com/out/build_new456.mp4",
+			},
+			{
+				"id":          "build_old123",
+				"status":      "failed",
+				"startedAt":   "2026-02-06T11:00:00Z",
+				"finishedAt":  "2026-02-06T11:01:00Z",
+				"triggeredBy": "bob@example.com",
+			},
+		},
+		"hasMore": false,
+	})
+
+	output, err := ExecuteCommand("productions", "builds", "prod_abc123")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "build_new456")
+	AssertContains(t, output, "build_old123")
+	AssertContains(t, output, "4m30s")
+	AssertContains(t, output, "alice@example.com")
+}
+
+func TestProductionsStatusWithBuild(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123/builds/build_old123", http.StatusOK, map[string]interface{}{
+		"id":              "prod_abc123",
+		"status":          "failed",
+		"buildId":         "build_old123",
+		"buildFinishedAt": "2026-02-06T11:01:00Z",
+	})
+
+	output, err := ExecuteCommand("productions", "status", "prod_abc123", "--build", "build_old123")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "failed")
+	AssertContains(t, output, "build_old123")
+}
+
+func TestProductionsLogs(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.Handle("GET", "/workspaces/ws_test123/productions/prod_abc123/build/logs", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("Step 1/3: rendering scenes\nStep 2/3: mixing audio\n"))
+	})
+
+	output, err := ExecuteCommand("productions", "logs", "prod_abc123")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "rendering scenes")
+	AssertContains(t, output, "mixing audio")
+}
+
+func TestProductionsDownload(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123/builds/build_old123", http.StatusOK, map[string]interface{}{
+		"id":        "prod_abc123",
+		"status":    "review",
+		"buildId":   "build_old123",
+		"outputUrl": tc.Server.URL + "/output/build_old123.mp4?sig=abc",
+	})
+
+	tc.Server.Handle("GET", "/output/build_old123.mp4", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("fake video content"))
+	})
+
+	outPath := filepath.Join(tc.ConfigDir, "final.mp4")
+
+	output, err := ExecuteCommand("productions", "download", "prod_abc123", "--build", "build_old123", "-o", outPath)
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("Output file not written: %v", err)
+	}
+	if string(data) != "fake video content" {
+		t.Errorf("Unexpected file content: %q", string(data))
+	}
+
+	AssertContains(t, output, "Downloaded")
+}
+
+func TestProductionsDownloadNotFound(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123/build", http.StatusOK, map[string]interface{}{
+		"id":        "prod_abc123",
+		"status":    "review",
+		"outputUrl": tc.Server.URL + "/output/missing.mp4",
+	})
+	tc.Server.Handle("GET", "/output/missing.mp4", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "NoSuchKey", http.StatusNotFound)
+	})
+
+	_, err := ExecuteCommand("productions", "download", "prod_abc123", "-o", filepath.Join(tc.ConfigDir, "out.mp4"))
+	if ExitCode(err) != ExitNotFound {
+		t.Errorf("Expected exit code %d, got %d (%v)", ExitNotFound, ExitCode(err), err)
+	}
+}
+
+func TestProductionsDownloadRemovesPartialFile(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123/build", http.StatusOK, map[string]interface{}{
+		"id":        "prod_abc123",
+		"status":    "review",
+		"outputUrl": tc.Server.URL + "/output/final.mp4",
+	})
+
+	// The connection drops before the promised length is sent
+	tc.Server.Handle("GET", "/output/final.mp4", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "1000")
+		w.Write([]byte("partial"))
+	})
+
+	outPath := filepath.Join(tc.ConfigDir, "final.mp4")
+
+	_, err := ExecuteCommand("productions", "download", "prod_abc123", "-o", outPath)
+	if err == nil {
+		t.Fatal("Expected error for a truncated download")
+	}
+	if _, err := os.Stat(outPath); !os.IsNotExist(err) {
+		t.Errorf("Expected partial file to be removed, got %v", err)
+	}
+}
+
+func TestDefaultOutputPath(t *testing.T) {
+	buildID := "build_xyz789"
+	outputURL := "https://storage.googleapis.com/out/final.mov?X-Goog-Signature=abc"
+
+	got := defaultOutputPath("prod_abc123", &buildRecord{BuildID: &buildID, OutputURL: &outputURL})
+	if got != "build_xyz789.mov" {
+		t.Errorf("Expected build_xyz789.mov, got %s", got)
+	}
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// suggestedChange is an edit to the production spec proposed by the
+// assistant. Path is a JSON Pointer (RFC 6901) into the spec; changes
+// without one are descriptive only and can't be applied automatically.
+type suggestedChange struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	Description string          `json:"description"`
+	Op          string          `json:"op"` // add, replace, remove
+	Path        string          `json:"path"`
+	Value       json.RawMessage `json:"value,omitempty"`
+}
+
+func (c suggestedChange) applicable() bool {
+	return c.Path != ""
+}
+
+// productionSpec is a production's spec and its version, used to detect
+// edits made since the assistant's suggestions
+type productionSpec struct {
+	Spec        map[string]interface{} `json:"spec"`
+	SpecVersion int                    `json:"specVersion"`
+}
+
+func fetchProductionSpec(apiKey, workspaceID, productionID string) (*productionSpec, error) {
+	url := fmt.Sprintf("%s/workspaces/%s/productions/%s", GetAPIURL(), workspaceID, productionID)
+
+	req, _ := http.NewRequest("GET", url, nil)
+	req.Header.Set("Authorization", apiKey)
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result productionSpec
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// applySpecChanges patches the production spec with the given changes in a
+// single update and returns the new spec version. baseVersion makes the
+// update fail if the spec was edited after it was read.
+func applySpecChanges(apiKey, workspaceID, productionID string, baseVersion int, changes []suggestedChange) (int, error) {
+	url := fmt.Sprintf("%s/workspaces/%s/productions/%s/spec", GetAPIURL(), workspaceID, productionID)
+
+	ops := make([]map[string]interface{}, len(changes))
+	for i, c := range changes {
+		op := c.Op
+		if op == "" {
+			op = "replace"
+		}
+		ops[i] = map[string]interface{}{"op": op, "path": c.Path}
+		if op != "remove" {
+			ops[i]["value"] = c.Value
+		}
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"baseVersion": baseVersion,
+		"operations":  ops,
+	})
+
+	req, _ := http.NewRequest("PATCH", url, bytes.NewReader(body))
+	req.Header.Set("Authorization", apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusConflict {
+		apiErr := newAPIError(resp)
+		apiErr.Message = "the spec was changed since these suggestions were made; ask the assistant again"
+		return 0, apiErr
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, newAPIError(resp)
+	}
+
+	var result struct {
+		SpecVersion int `json:"specVersion"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return result.SpecVersion, nil
+}
+
+// printChangeDiff shows a numbered change as a diff against the current spec.
+// spec may be nil when it couldn't be loaded.
+func printChangeDiff(n int, c suggestedChange, spec map[string]interface{}) {
+	fmt.Printf("  [%d] %s\n", n, c.Description)
+	if !c.applicable() {
+		return
+	}
+
+	color := isTerminal(os.Stdout)
+	line := func(prefix, text, code string) {
+		if color {
+			fmt.Printf("      \033[%sm%s %s\033[0m\n", code, prefix, text)
+		} else {
+			fmt.Printf("      %s %s\n", prefix, text)
+		}
+	}
+
+	fmt.Printf("      %s %s\n", valueOrDash(c.Op), c.Path)
+
+	if spec != nil && c.Op != "add" {
+		if old, ok := jsonPointerGet(spec, c.Path); ok {
+			for _, l := range diffLines(old) {
+				line("-", l, "31")
+			}
+		}
+	}
+	if c.Op != "remove" && len(c.Value) > 0 {
+		var value interface{}
+		json.Unmarshal(c.Value, &value)
+		for _, l := range diffLines(value) {
+			line("+", l, "32")
+		}
+	}
+}
+
+// diffLines renders a spec value for a diff: strings as text, anything else
+// as indented JSON
+func diffLines(v interface{}) []string {
+	if s, ok := v.(string); ok {
+		return strings.Split(s, "\n")
+	}
+	data, _ := json.MarshalIndent(v, "", "  ")
+	return strings.Split(string(data), "\n")
+}
+
+// jsonPointerGet resolves an RFC 6901 JSON Pointer against a decoded JSON
+// document
+func jsonPointerGet(doc interface{}, pointer string) (interface{}, bool) {
+	if pointer == "" {
+		return doc, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+
+	cur := doc
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch node := cur.(type) {
+		case map[string]interface{}:
+			v, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			cur = v
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			cur = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func handleChangesThread(tc *TestConfig) {
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/productions/prod_abc123/thread", http.StatusOK, map[string]interface{}{
+		"assistantMessage": ThreadMessageResponse{Role: "assistant", Content: "Here are two edits."},
+		"suggestedChanges": []map[string]interface{}{
+			{"id": "chg_1", "type": "update_section", "description": "Stronger hook", "op": "replace", "path": "/scenes/0/script", "value": "Stop scrolling."},
+			{"id": "chg_2", "type": "update_section", "description": "Shorter outro", "op": "replace", "path": "/scenes/1/duration", "value": 5},
+		},
+	})
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123", http.StatusOK, map[string]interface{}{
+		"id":          "prod_abc123",
+		"specVersion": 3,
+		"spec": map[string]interface{}{
+			"scenes": []interface{}{
+				map[string]interface{}{"script": "Welcome back."},
+				map[string]interface{}{"duration": 10},
+			},
+		},
+	})
+}
+
+func TestThreadChatApplyAll(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	handleChangesThread(tc)
+
+	var patch struct {
+		BaseVersion int                      `json:"baseVersion"`
+		Operations  []map[string]interface{} `json:"operations"`
+	}
+	tc.Server.Handle("PATCH", "/workspaces/ws_test123/productions/prod_abc123/spec", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &patch)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]int{"specVersion": 4})
+	})
+
+	output, err := ExecuteCommand("thread", "chat", "-p", "prod_abc123", "--apply-all", "Improve it")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "[1] Stronger hook")
+	AssertContains(t, output, "- Welcome back.")
+	AssertContains(t, output, "+ Stop scrolling.")
+	AssertContains(t, output, "- 10")
+	AssertContains(t, output, "+ 5")
+	AssertContains(t, output, "Applied 2 changes to prod_abc123 (spec version 4)")
+
+	if patch.BaseVersion != 3 {
+		t.Errorf("Expected baseVersion 3, got %d", patch.BaseVersion)
+	}
+	if len(patch.Operations) != 2 || patch.Operations[0]["path"] != "/scenes/0/script" || patch.Operations[0]["value"] != "Stop scrolling." {
+		t.Errorf("Unexpected operations: %v", patch.Operations)
+	}
+}
+
+func TestThreadChatInteractiveApply(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	handleChangesThread(tc)
+
+	var patch struct {
+		Operations []map[string]interface{} `json:"operations"`
+	}
+	tc.Server.Handle("PATCH", "/workspaces/ws_test123/productions/prod_abc123/spec", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &patch)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]int{"specVersion": 4})
+	})
+
+	var err error
+	output := CaptureOutput(func() {
+		_, err = ExecuteCommandWithStdin("Improve it\n/reject 1\n/apply 1\n/apply all\nexit\n", "thread", "chat", "-p", "prod_abc123")
+	})
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "/apply N")
+	AssertContains(t, output, "Rejected change 1")
+	AssertContains(t, output, "change 1 was already applied or rejected")
+	AssertContains(t, output, "Applied 1 change to prod_abc123")
+
+	if len(patch.Operations) != 1 || patch.Operations[0]["path"] != "/scenes/1/duration" {
+		t.Errorf("Expected only change 2 to be applied, got %v", patch.Operations)
+	}
+}
+
+func TestThreadChatApplyConflict(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	handleChangesThread(tc)
+	tc.Server.HandleJSON("PATCH", "/workspaces/ws_test123/productions/prod_abc123/spec", http.StatusConflict, map[string]int{
+		"specVersion": 5,
+	})
+
+	_, err := ExecuteCommand("thread", "chat", "-p", "prod_abc123", "--apply-all", "Improve it")
+	if err == nil {
+		t.Fatal("Expected error on spec conflict")
+	}
+	AssertContains(t, err.Error(), "spec was changed")
+}
+
+func TestThreadChatApplySendsShownVersion(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	handleChangesThread(tc)
+
+	// The spec is edited after the changes were shown
+	gets := 0
+	tc.Server.Handle("GET", "/workspaces/ws_test123/productions/prod_abc123", func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		version := 3
+		if gets > 1 {
+			version = 5
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "prod_abc123", "specVersion": version, "spec": map[string]interface{}{}})
+	})
+
+	var patches []struct {
+		BaseVersion int                      `json:"baseVersion"`
+		Operations  []map[string]interface{} `json:"operations"`
+	}
+	tc.Server.Handle("PATCH", "/workspaces/ws_test123/productions/prod_abc123/spec", func(w http.ResponseWriter, r *http.Request) {
+		var patch struct {
+			BaseVersion int                      `json:"baseVersion"`
+			Operations  []map[string]interface{} `json:"operations"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &patch)
+		patches = append(patches, patch)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]int{"specVersion": patch.BaseVersion + 1})
+	})
+
+	var err error
+	CaptureOutput(func() {
+		_, err = ExecuteCommandWithStdin("Improve it\n/apply 1 1\n/apply 2\nexit\n", "thread", "chat", "-p", "prod_abc123")
+	})
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if len(patches) != 2 {
+		t.Fatalf("Expected 2 PATCH requests, got %d", len(patches))
+	}
+	if patches[0].BaseVersion != 3 || len(patches[0].Operations) != 1 {
+		t.Errorf("Expected one operation against version 3, got %+v", patches[0])
+	}
+	// Later changes apply on top of the session's own edits
+	if patches[1].BaseVersion != 4 {
+		t.Errorf("Expected baseVersion 4, got %d", patches[1].BaseVersion)
+	}
+}
+
+func TestThreadChatApplyUsesReplyVersion(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	handleChangesThread(tc)
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/productions/prod_abc123/thread", http.StatusOK, map[string]interface{}{
+		"assistantMessage": ThreadMessageResponse{Role: "assistant", Content: "One edit."},
+		"specVersion":      2,
+		"suggestedChanges": []map[string]interface{}{
+			{"id": "chg_1", "type": "update_section", "description": "Stronger hook", "op": "replace", "path": "/scenes/0/script", "value": "Stop scrolling."},
+		},
+	})
+
+	var patch struct {
+		BaseVersion int `json:"baseVersion"`
+	}
+	tc.Server.Handle("PATCH", "/workspaces/ws_test123/productions/prod_abc123/spec", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &patch)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]int{"specVersion": 4})
+	})
+
+	if _, err := ExecuteCommand("thread", "chat", "-p", "prod_abc123", "--apply-all", "Improve it"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if patch.BaseVersion != 2 {
+		t.Errorf("Expected baseVersion 2 from the reply, got %d", patch.BaseVersion)
+	}
+}
+
+func TestThreadChatApplyAllRequiresProduction(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	_, err := ExecuteCommand("thread", "chat", "--apply-all", "Improve it")
+	if err == nil {
+		t.Fatal("Expected error without --production")
+	}
+}
+
+func TestJSONPointerGet(t *testing.T) {
+	var doc interface{}
+	json.Unmarshal([]byte(`{"scenes":[{"script":"hi"}],"a/b":{"m~n":1}}`), &doc)
+
+	tests := []struct {
+		pointer string
+		want    interface{}
+		ok      bool
+	}{
+		{"/scenes/0/script", "hi", true},
+		{"/a~1b/m~0n", float64(1), true},
+		{"/scenes/1", nil, false},
+		{"/missing", nil, false},
+		{"scenes", nil, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := jsonPointerGet(doc, tt.pointer)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("jsonPointerGet(%q) = %v, %v; want %v, %v", tt.pointer, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	mathrand "math/rand"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultTimeout        = 2 * time.Minute
+	defaultConnectTimeout = 10 * time.Second
+	defaultRetries        = 3
+
+	// maxRetryWait caps a single wait, including one asked for with
+	// Retry-After; longer waits give up and return the response
+	maxRetryWait = time.Minute
+)
+
+var (
+	// retryBaseDelay is the first backoff delay, doubled on each retry
+	retryBaseDelay = 500 * time.Millisecond
+
+	// retrySleep waits between attempts; tests replace it
+	retrySleep = time.Sleep
+)
+
+var (
+	clientMu sync.Mutex
+	clients  = make(map[[2]time.Duration]*http.Client)
+)
+
+// slowResponseKey marks a request whose response can take longer than the
+// default --timeout to start; see withSlowResponse
+type slowResponseKey struct{}
+
+// apiClient returns the HTTP client shared by all requests with the same
+// connect and response header timeouts. There's no overall timeout, since
+// streams and downloads run for as long as they need; instead connecting and
+// waiting for the response headers are limited. A zero timeout means none.
+func apiClient(connectTimeout, responseTimeout time.Duration) *http.Client {
+	settings := [2]time.Duration{connectTimeout, responseTimeout}
+
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
+	if c, ok := clients[settings]; ok {
+		return c
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: connectTimeout, KeepAlive: 30 * time.Second}).DialContext
+	transport.TLSHandshakeTimeout = connectTimeout
+	transport.ResponseHeaderTimeout = responseTimeout
+
+	c := &http.Client{Transport: transport}
+	clients[settings] = c
+	return c
+}
+
+// setIdempotencyKey lets doRequest retry a POST after a network error or a
+// 502/503/504, since the server drops a repeat carrying the same key. Only
+// set it for endpoints that honor the key.
+func setIdempotencyKey(req *http.Request) {
+	req.Header.Set("Idempotency-Key", newIdempotencyKey())
+}
+
+// withSlowResponse exempts req from the default response timeout, for
+// requests such as chat messages whose reply is generated before the
+// response starts. A --timeout the user set still applies.
+func withSlowResponse(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), slowResponseKey{}, true))
+}
+
+// responseTimeout returns how long to wait for the response headers to req
+func responseTimeout(req *http.Request) time.Duration {
+	if req.Context().Value(slowResponseKey{}) != nil {
+		return durationSetting("timeout", "timeout", 0)
+	}
+	return durationSetting("timeout", "timeout", defaultTimeout)
+}
+
+// doRequest sends req with the shared client, retrying with exponential
+// backoff and jitter when it's safe to:
+//
+//   - 429 responses are always retried, since the request wasn't processed
+//   - network errors and 502/503/504 responses are retried for idempotent
+//     requests, which includes POSTs given an Idempotency-Key with
+//     setIdempotencyKey
+//
+// Retry-After is honored on 429 and 503. Requests whose body can't be
+// replayed (such as streamed uploads) are never retried.
+func doRequest(req *http.Request) (*http.Response, error) {
+	httpClient := apiClient(durationSetting("connect-timeout", "connect_timeout", defaultConnectTimeout), responseTimeout(req))
+
+	retries := retriesSetting()
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := httpClient.Do(req)
+
+		if attempt >= retries || !replayable || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := backoff(attempt)
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			if after, ok := retryAfter(resp); ok {
+				wait = after
+			}
+		}
+
+		// Give up rather than wait a long time; the caller reports the
+		// response, including when to try again
+		if wait > maxRetryWait {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		fmt.Fprintf(os.Stderr, "Retrying in %s (%s)...\n", wait.Round(100*time.Millisecond), reason)
+		retrySleep(wait)
+	}
+}
+
+// shouldRetry reports whether a failed attempt may be retried
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// A cancelled request isn't retried
+		if req.Context().Err() != nil {
+			return false
+		}
+		return isIdempotent(req)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req)
+	}
+	return false
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// backoff returns the delay before retry attempt+1: exponential, with up to
+// half of it random so concurrent clients don't retry in step
+func backoff(attempt int) time.Duration {
+	d := retryBaseDelay << attempt
+	if d > maxRetryWait || d <= 0 {
+		d = maxRetryWait
+	}
+	return d/2 + time.Duration(mathrand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses the Retry-After header of a 429 or 503 response, given
+// in seconds or as an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+// newIdempotencyKey returns a random key so the server can drop duplicate
+// POSTs when a retry follows a request that did arrive
+func newIdempotencyKey() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// durationSetting reads a duration from its global flag, else the config
+// file or HY_* environment variable
+func durationSetting(flag, key string, def time.Duration) time.Duration {
+	if f := rootCmd.PersistentFlags().Lookup(flag); f != nil && f.Changed {
+		if d, err := time.ParseDuration(f.Value.String()); err == nil {
+			return d
+		}
+	}
+	if d := viper.GetDuration(key); d > 0 {
+		return d
+	}
+	return def
+}
+
+func retriesSetting() int {
+	if f := rootCmd.PersistentFlags().Lookup("retries"); f != nil && f.Changed {
+		if n, err := strconv.Atoi(f.Value.String()); err == nil {
+			return n
+		}
+	}
+	if viper.IsSet("retries") {
+		return viper.GetInt("retries")
+	}
+	return defaultRetries
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// recordSleeps replaces retrySleep with one that records the waits
+func recordSleeps() *[]time.Duration {
+	var sleeps []time.Duration
+	retrySleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+	return &sleeps
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	sleeps := recordSleeps()
+	calls := 0
+	tc.Server.Handle("GET", "/ping", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req, _ := http.NewRequest("GET", tc.Server.URL+"/ping", nil)
+	resp, err := doRequest(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK || calls != 2 {
+		t.Errorf("Expected success on second attempt, got %d after %d calls", resp.StatusCode, calls)
+	}
+	if len(*sleeps) != 1 || (*sleeps)[0] != 3*time.Second {
+		t.Errorf("Expected to wait 3s, got %v", *sleeps)
+	}
+}
+
+func TestRetryGivesUpAfterRetries(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	sleeps := recordSleeps()
+	calls := 0
+	tc.Server.Handle("GET", "/ping", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	req, _ := http.NewRequest("GET", tc.Server.URL+"/ping", nil)
+	resp, err := doRequest(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable || calls != defaultRetries+1 {
+		t.Errorf("Expected %d attempts ending in 503, got %d ending in %d", defaultRetries+1, calls, resp.StatusCode)
+	}
+
+	// Backoff grows exponentially
+	for i, d := range *sleeps {
+		base := retryBaseDelay << i
+		if d < base/2 || d > base {
+			t.Errorf("Wait %d = %v, want between %v and %v", i, d, base/2, base)
+		}
+	}
+}
+
+func TestRetrySkipsNonIdempotentRequests(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	calls := 0
+	tc.Server.Handle("PATCH", "/ping", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	req, _ := http.NewRequest("PATCH", tc.Server.URL+"/ping", strings.NewReader("{}"))
+	resp, err := doRequest(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if calls != 1 {
+		t.Errorf("PATCH without an idempotency key should not be retried, got %d calls", calls)
+	}
+}
+
+func TestRetryPostWithIdempotencyKey(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var keys, bodies []string
+	tc.Server.Handle("POST", "/workspaces/ws_test123/productions/prod_abc123/threads", func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(keys) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "thr_hooks", "name": "hooks"}`))
+	})
+
+	output, err := ExecuteCommand("thread", "new", "-p", "prod_abc123", "--name", "hooks")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	AssertContains(t, output, "thr_hooks")
+
+	if len(keys) != 3 || keys[0] == "" || keys[0] != keys[1] || keys[1] != keys[2] {
+		t.Errorf("Expected the same idempotency key on every attempt, got %q", keys)
+	}
+	if bodies[0] != bodies[2] {
+		t.Errorf("Expected the body to be replayed, got %q", bodies)
+	}
+}
+
+func TestChatMessageNotRetried(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var keys []string
+	tc.Server.Handle("POST", "/workspaces/ws_test123/thread", func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	_, err := ExecuteCommand("thread", "chat", "--no-stream", "hi")
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if len(keys) != 1 || keys[0] != "" {
+		t.Errorf("Expected one attempt without an idempotency key, got %q", keys)
+	}
+}
+
+func TestSlowResponseTimeout(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	req, _ := http.NewRequest("POST", tc.Server.URL, nil)
+	if got := responseTimeout(req); got != defaultTimeout {
+		t.Errorf("Expected the default timeout, got %s", got)
+	}
+	if got := responseTimeout(withSlowResponse(req)); got != 0 {
+		t.Errorf("Expected no default timeout for a slow response, got %s", got)
+	}
+
+	viper.Set("timeout", "5m")
+	if got := responseTimeout(withSlowResponse(req)); got != 5*time.Minute {
+		t.Errorf("Expected the configured timeout, got %s", got)
+	}
+}
+
+func TestRetryNetworkError(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	sleeps := recordSleeps()
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	req, _ := http.NewRequest("GET", url, nil)
+	if _, err := doRequest(req); err == nil {
+		t.Fatal("Expected error for unreachable server")
+	}
+	if len(*sleeps) != defaultRetries {
+		t.Errorf("Expected %d retries, got %d", defaultRetries, len(*sleeps))
+	}
+}
+
+func TestRetryAfterTooLong(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.Handle("GET", "/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	req, _ := http.NewRequest("GET", tc.Server.URL+"/ping", nil)
+	resp, err := doRequest(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Expected the 429 response without waiting, got %d", resp.StatusCode)
+	}
+	if apiErr := newAPIError(resp); !strings.Contains(apiErr.Error(), "Try again in 1h0m0s") {
+		t.Errorf("Expected retry time in error, got %q", apiErr.Error())
+	}
+}
+
+func TestRetriesFlag(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	calls := 0
+	tc.Server.Handle("GET", "/workspaces/ws_test123/productions/prod_abc123", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, err := ExecuteCommand("productions", "get", "prod_abc123", "--retries", "0")
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if calls != 1 {
+		t.Errorf("Expected no retries, got %d calls", calls)
+	}
+}
+
+func TestTimeoutFlag(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.Handle("GET", "/workspaces/ws_test123/productions/prod_abc123", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	_, err := ExecuteCommand("productions", "get", "prod_abc123", "--timeout", "50ms", "--retries", "0")
+	if err == nil {
+		t.Fatal("Expected timeout error")
+	}
+	AssertContains(t, err.Error(), "timeout")
+}
@@ -24,10 +24,13 @@ Available keys:
 Examples:
   hy config get api_url
   hy config get workspace_id`,
-	Args: cobra.ExactArgs(1),
+	Args: usageArgs(cobra.ExactArgs(1)),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := args[0]
 		value := viper.GetString(key)
+		if _, ok := profileFlags[key]; ok {
+			value = profileSetting(key)
+		}
 
 		if value == "" {
 			fmt.Printf("%s is not set\n", key)
@@ -48,21 +51,25 @@ Available keys:
   api_url       API base URL
   workspace_id  Current workspace ID
 
+api_url and workspace_id are stored in the active profile.
+
 Examples:
   hy config set api_url https://studio.hypewell.ai/api
   hy config set workspace_id ws_abc123`,
-	Args: cobra.ExactArgs(2),
+	Args: usageArgs(cobra.ExactArgs(2)),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		key := args[0]
 		value := args[1]
 
-		viper.Set(key, value)
+		// Profile-scoped keys are written to the active profile
+		if _, ok := profileFlags[key]; ok {
+			setProfileSetting(key, value)
+		} else {
+			viper.Set(key, value)
+		}
 
-		if err := viper.WriteConfig(); err != nil {
-			// Try to create the config file if it doesn't exist
-			if err := viper.SafeWriteConfig(); err != nil {
-				return fmt.Errorf("failed to save config: %w", err)
-			}
+		if err := saveConfig(); err != nil {
+			return err
 		}
 
 		fmt.Printf("✓ Set %s = %s\n", key, value)
@@ -0,0 +1,371 @@
+package cmd
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/zalando/go-keyring"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Credential stores
+const (
+	storeKeyring = "keyring"
+	storeFile    = "file"
+	storeEnv     = "env"
+)
+
+const (
+	credentialsFileName = "credentials.enc"
+	pbkdf2Iterations    = 600000
+
+	// credentialsVersion 2 derives the machine key from the machine ID and
+	// user ID only; version 1 also used the host and user names
+	credentialsVersion = 2
+)
+
+// credentialsFile is the on-disk format of the encrypted file store. The
+// plaintext is a JSON object mapping keyring accounts to API keys.
+type credentialsFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	KeySource  string `json:"keySource"` // passphrase or machine
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+var authMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Move plaintext API keys out of the config file",
+	Long: `Move API keys stored in plaintext in config.yaml (from older versions
+of hy) into the configured credential store, then remove them from the
+config file. Every profile is migrated.
+
+Examples:
+  hy auth migrate
+  hy auth migrate --to file`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, _ := cmd.Flags().GetString("to")
+		if store == "" {
+			store = credentialStore()
+		}
+		if store != storeKeyring && store != storeFile {
+			return fmt.Errorf("can only migrate to the keyring or file store")
+		}
+
+		// Copy every key into the store before touching the config, so a
+		// failure leaves the config file as it was
+		var names, keys, stores []string
+		for _, name := range profileNames() {
+			key := viper.GetString(profileKey(name, "api_key"))
+			if key == "" {
+				continue
+			}
+
+			used, err := saveAPIKey(name, key, store)
+			if err != nil {
+				return fmt.Errorf("failed to migrate profile %s: %w (the config file was not changed)", name, err)
+			}
+			names = append(names, name)
+			keys = append(keys, profileKey(name, "api_key"))
+			stores = append(stores, used)
+		}
+
+		if len(names) == 0 {
+			fmt.Println("No plaintext API keys found")
+			return nil
+		}
+
+		if err := saveConfigWithout(keys...); err != nil {
+			return err
+		}
+		for i, name := range names {
+			fmt.Printf("✓ Migrated %s profile key to %s store\n", name, stores[i])
+		}
+		return nil
+	},
+}
+
+// credentialStore returns the configured credential store (default keyring)
+func credentialStore() string {
+	if f := rootCmd.PersistentFlags().Lookup("credential-store"); f != nil && f.Changed {
+		return f.Value.String()
+	}
+	if store := viper.GetString("credential_store"); store != "" {
+		return store
+	}
+	return storeKeyring
+}
+
+func validateCredentialStore() error {
+	switch credentialStore() {
+	case storeKeyring, storeFile, storeEnv:
+		return nil
+	default:
+		return fmt.Errorf("invalid credential store %q (want keyring, file or env)", credentialStore())
+	}
+}
+
+// saveAPIKey stores a profile's API key in the given store and returns the
+// store actually used. The keyring store falls back to the encrypted file
+// when no OS keyring is available.
+func saveAPIKey(profile, apiKey, store string) (string, error) {
+	account := keyringAccount(profile)
+
+	switch store {
+	case storeEnv:
+		return "", fmt.Errorf("credential store is env; set HY_API_KEY instead of storing the key")
+	case storeKeyring:
+		err := keyring.Set(serviceName, account, apiKey)
+		if err == nil {
+			return storeKeyring, nil
+		}
+		fmt.Printf("Warning: Could not store in system keyring (%v), using encrypted file\n", err)
+	}
+
+	creds, err := readCredentialsFile()
+	if err != nil {
+		return "", err
+	}
+	creds[account] = apiKey
+	if err := writeCredentialsFile(creds); err != nil {
+		return "", err
+	}
+	return storeFile, nil
+}
+
+// loadAPIKey looks up a profile's API key in the keyring, then the encrypted
+// file. It returns the store the key came from.
+func loadAPIKey(profile string) (string, string) {
+	account := keyringAccount(profile)
+
+	if credentialStore() == storeEnv {
+		return "", ""
+	}
+
+	if credentialStore() == storeKeyring {
+		if key, err := keyring.Get(serviceName, account); err == nil {
+			return key, storeKeyring
+		}
+	}
+
+	if creds, err := readCredentialsFile(); err == nil && creds[account] != "" {
+		return creds[account], storeFile
+	}
+
+	return "", ""
+}
+
+// deleteAPIKey removes a profile's API key from the keyring and the
+// encrypted file. Missing entries are not an error, and neither is a
+// missing keyring: nothing can have been stored in it.
+func deleteAPIKey(profile string) error {
+	account := keyringAccount(profile)
+	var errs []error
+
+	// The file store never uses the keyring. Other errors mean there's no
+	// usable keyring (e.g. no D-Bus on a headless machine), so saveAPIKey
+	// fell back to the file.
+	if credentialStore() != storeFile {
+		keyring.Delete(serviceName, account)
+	}
+
+	if _, err := os.Stat(credentialsPath()); err == nil {
+		creds, err := readCredentialsFile()
+		if err != nil {
+			errs = append(errs, err)
+		} else if _, ok := creds[account]; ok {
+			delete(creds, account)
+			if err := writeCredentialsFile(creds); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func credentialsPath() string {
+	return filepath.Join(configDir(), credentialsFileName)
+}
+
+// readCredentialsFile decrypts the credentials file. A missing file is an
+// empty store.
+func readCredentialsFile() (map[string]string, error) {
+	data, err := os.ReadFile(credentialsPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %w", err)
+	}
+
+	var file credentialsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("credentials file is corrupt: %w", err)
+	}
+
+	secret, _, err := credentialSecret()
+	if err != nil {
+		return nil, err
+	}
+	legacy := file.Version < 2 && file.KeySource == "machine"
+	if legacy {
+		secret = legacyMachineSecret()
+	}
+
+	gcm, err := newCredentialsCipher(secret, file.Salt, file.Iterations)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		if file.KeySource == "passphrase" {
+			return nil, fmt.Errorf("failed to decrypt credentials file: wrong HY_CREDENTIAL_PASSPHRASE?")
+		}
+		return nil, fmt.Errorf("failed to decrypt credentials file (was it copied from another machine?)")
+	}
+
+	creds := map[string]string{}
+	if err := json.Unmarshal(plaintext, &creds); err != nil {
+		return nil, fmt.Errorf("credentials file is corrupt: %w", err)
+	}
+
+	// Re-encrypt old files so renaming the host doesn't lock them. If that
+	// fails the file is still readable as before.
+	if legacy {
+		writeCredentialsFile(creds)
+	}
+	return creds, nil
+}
+
+// writeCredentialsFile encrypts creds with a fresh salt and nonce and writes
+// them with 0600 permissions
+func writeCredentialsFile(creds map[string]string) error {
+	secret, source, err := credentialSecret()
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+
+	gcm, err := newCredentialsCipher(secret, salt, pbkdf2Iterations)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	plaintext, _ := json.Marshal(creds)
+
+	data, _ := json.MarshalIndent(credentialsFile{
+		Version:    credentialsVersion,
+		KDF:        "pbkdf2-sha256",
+		Iterations: pbkdf2Iterations,
+		KeySource:  source,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	}, "", "  ")
+
+	if err := os.MkdirAll(configDir(), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	// Write to a temp file and rename so a crash never leaves a partial file
+	tmp := credentialsPath() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	if err := os.Rename(tmp, credentialsPath()); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write credentials file: %w", err)
+	}
+	return nil
+}
+
+func newCredentialsCipher(secret, salt []byte, iterations int) (cipher.AEAD, error) {
+	if iterations <= 0 {
+		return nil, fmt.Errorf("credentials file is corrupt: invalid iteration count")
+	}
+	block, err := aes.NewCipher(pbkdf2.Key(secret, salt, iterations, 32, sha256.New))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// credentialSecret returns the secret the file key is derived from:
+// HY_CREDENTIAL_PASSPHRASE when set, otherwise a machine key. The machine
+// key stops the file being usable elsewhere but does not protect it from
+// other processes running as the same user. It's built from values that
+// don't change when the host or user is renamed.
+func credentialSecret() ([]byte, string, error) {
+	if passphrase := os.Getenv("HY_CREDENTIAL_PASSPHRASE"); passphrase != "" {
+		return []byte(passphrase), "passphrase", nil
+	}
+
+	var parts []string
+	if id := machineID(); id != "" {
+		parts = append(parts, id)
+	}
+	if u, err := user.Current(); err == nil {
+		parts = append(parts, u.Uid)
+	}
+	if len(parts) == 0 {
+		return nil, "", fmt.Errorf("cannot derive a machine key; set HY_CREDENTIAL_PASSPHRASE")
+	}
+
+	return []byte("hy-machine-key:" + strings.Join(parts, ":")), "machine", nil
+}
+
+// legacyMachineSecret is the machine key of version 1 files, which also
+// included the host and user names
+func legacyMachineSecret() []byte {
+	var parts []string
+	if id := machineID(); id != "" {
+		parts = append(parts, id)
+	}
+	if host, err := os.Hostname(); err == nil {
+		parts = append(parts, host)
+	}
+	if u, err := user.Current(); err == nil {
+		parts = append(parts, u.Uid, u.Username)
+	}
+	return []byte("hy-machine-key:" + strings.Join(parts, ":"))
+}
+
+func machineID() string {
+	for _, path := range []string{"/etc/machine-id", "/var/lib/dbus/machine-id"} {
+		if id, err := os.ReadFile(path); err == nil {
+			return strings.TrimSpace(string(id))
+		}
+	}
+	return ""
+}
+
+func init() {
+	authCmd.AddCommand(authMigrateCmd)
+
+	authMigrateCmd.Flags().String("to", "", "Target store: keyring or file (default is the configured store)")
+}
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/zalando/go-keyring"
+)
+
+func TestCredentialsFileRoundTrip(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	t.Setenv("HY_CREDENTIAL_PASSPHRASE", "correct horse")
+
+	if err := writeCredentialsFile(map[string]string{"api-key": "sk_live_secret"}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	info, err := os.Stat(credentialsPath())
+	if err != nil {
+		t.Fatalf("Credentials file not written: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected 0600 permissions, got %o", info.Mode().Perm())
+	}
+
+	data, _ := os.ReadFile(credentialsPath())
+	if strings.Contains(string(data), "sk_live_secret") {
+		t.Error("Credentials file contains the plaintext key")
+	}
+
+	creds, err := readCredentialsFile()
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if creds["api-key"] != "sk_live_secret" {
+		t.Errorf("Expected key to round-trip, got %q", creds["api-key"])
+	}
+
+	t.Setenv("HY_CREDENTIAL_PASSPHRASE", "wrong")
+	if _, err := readCredentialsFile(); err == nil {
+		t.Error("Expected error decrypting with the wrong passphrase")
+	}
+}
+
+func TestMachineKeyIgnoresHostname(t *testing.T) {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		t.Skip("no hostname")
+	}
+
+	secret, source, err := credentialSecret()
+	if err != nil {
+		t.Fatalf("No machine key: %v", err)
+	}
+	if source != "machine" {
+		t.Fatalf("Expected machine key, got %s", source)
+	}
+	if strings.Contains(string(secret), ":"+host) {
+		t.Error("Machine key should not depend on the hostname")
+	}
+}
+
+func TestLegacyCredentialsFileIsUpgraded(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	// Write a version 1 file, keyed with the host and user names
+	salt := []byte("0123456789abcdef")
+	gcm, err := newCredentialsCipher(legacyMachineSecret(), salt, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	plaintext, _ := json.Marshal(map[string]string{"api-key": "sk_live_legacy"})
+	data, _ := json.Marshal(credentialsFile{
+		Version:    1,
+		KDF:        "pbkdf2-sha256",
+		Iterations: 1000,
+		KeySource:  "machine",
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	})
+	os.MkdirAll(configDir(), 0700)
+	if err := os.WriteFile(credentialsPath(), data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	creds, err := readCredentialsFile()
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if creds["api-key"] != "sk_live_legacy" {
+		t.Errorf("Expected legacy key, got %v", creds)
+	}
+
+	var file credentialsFile
+	data, _ = os.ReadFile(credentialsPath())
+	json.Unmarshal(data, &file)
+	if file.Version != credentialsVersion {
+		t.Errorf("Expected file to be re-encrypted as version %d, got %d", credentialsVersion, file.Version)
+	}
+	if creds, err := readCredentialsFile(); err != nil || creds["api-key"] != "sk_live_legacy" {
+		t.Errorf("Upgraded file not readable: %v, %v", creds, err)
+	}
+}
+
+func TestSaveAPIKeyFallsBackToFile(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	keyring.MockInitWithError(errors.New("no secret service"))
+
+	var used string
+	var err error
+	CaptureOutput(func() {
+		used, err = saveAPIKey(defaultProfile, "sk_live_headless", storeKeyring)
+	})
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if used != storeFile {
+		t.Errorf("Expected fallback to file store, got %s", used)
+	}
+
+	if key, source := loadAPIKey(defaultProfile); key != "sk_live_headless" || source != storeFile {
+		t.Errorf("Expected key from file store, got %q from %q", key, source)
+	}
+	if viper.GetString("api_key") != "" {
+		t.Error("Key should not be written to config in plaintext")
+	}
+}
+
+func TestLogoutWithoutKeyring(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	keyring.MockInitWithError(errors.New("no secret service"))
+
+	CaptureOutput(func() {
+		saveAPIKey(defaultProfile, "sk_live_headless", storeKeyring)
+	})
+
+	output, err := ExecuteCommand("auth", "logout", "--local-only")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	AssertContains(t, output, "Logged out")
+
+	creds, err := readCredentialsFile()
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if _, ok := creds[keyringAccount(defaultProfile)]; ok {
+		t.Error("Expected key to be removed from the credentials file")
+	}
+}
+
+func TestProfileRemoveFileStoreWithoutKeyring(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	keyring.MockInitWithError(errors.New("no secret service"))
+	viper.Set("profiles.staging.api_url", "https://staging.example.com/api")
+
+	output, err := ExecuteCommand("--credential-store", "file", "profile", "remove", "staging")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if strings.Contains(output, "Warning") {
+		t.Errorf("Expected no warning without a keyring:\n%s", output)
+	}
+	AssertContains(t, output, "Removed profile: staging")
+}
+
+func TestAuthMigrate(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	viper.Set("profiles.staging.api_key", "sk_live_staging_plain")
+
+	output, err := ExecuteCommand("auth", "migrate", "--to", "file")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	// ExecuteCommand sets a plaintext default-profile key too
+	AssertContains(t, output, "Migrated default profile")
+	AssertContains(t, output, "Migrated staging profile")
+
+	if viper.GetString("profiles.staging.api_key") != "" || viper.GetString("api_key") != "" {
+		t.Error("Plaintext keys should be removed from config")
+	}
+
+	creds, err := readCredentialsFile()
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if creds[keyringAccount("staging")] != "sk_live_staging_plain" {
+		t.Errorf("Expected staging key in file store, got %v", creds)
+	}
+
+	config, _ := os.ReadFile(cfgFile)
+	if strings.Contains(string(config), "sk_live") || strings.Contains(string(config), "api_key") {
+		t.Errorf("Config file still contains a key:\n%s", config)
+	}
+}
+
+func TestAuthMigrateFailureLeavesConfig(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	viper.Set("profiles.staging.api_key", "sk_live_staging_plain")
+	viper.SetConfigFile(cfgFile)
+	saveConfig()
+
+	// A directory where the credentials file should be makes saving fail
+	os.MkdirAll(credentialsPath(), 0700)
+
+	if _, err := ExecuteCommand("auth", "migrate", "--to", "file"); err == nil {
+		t.Fatal("Expected error when the store can't be written")
+	}
+
+	config, _ := os.ReadFile(cfgFile)
+	if !strings.Contains(string(config), "sk_live_staging_plain") {
+		t.Errorf("Config should be unchanged after a failed migration:\n%s", config)
+	}
+}
+
+func TestInvalidCredentialStore(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	_, err := ExecuteCommand("--credential-store", "vault", "productions", "list")
+	if err == nil {
+		t.Fatal("Expected error for invalid credential store")
+	}
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Exit codes, so scripts can tell failures apart. Keep in sync with the
+// README.
+const (
+	ExitError      = 1 // any other error
+	ExitUsage      = 2 // invalid command-line flags or arguments
+	ExitAuth       = 3 // not logged in, invalid key or missing permission (401, 403)
+	ExitNotFound   = 4 // resource doesn't exist (404)
+	ExitConflict   = 5 // resource is in the wrong state (409)
+	ExitValidation = 6 // the API rejected the request (400, 422)
+	ExitNetwork    = 7 // the API couldn't be reached or timed out
+	ExitServer     = 8 // rate limited or server error (429, 5xx); try again later
+)
+
+// errNotAuthenticated is returned when no API key is configured
+var errNotAuthenticated = errors.New("not authenticated. Run 'hy auth login' first")
+
+// APIError is an error response from the API
+type APIError struct {
+	StatusCode    int
+	Code          string
+	Message       string
+	RequestID     string
+	RequiredScope string
+	Fields        []FieldError
+	RetryAfter    time.Duration
+}
+
+// FieldError describes an invalid request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// apiErrorBody is the JSON error format. "error" is either a message or an
+// object with the other fields.
+type apiErrorBody struct {
+	Code          string       `json:"code"`
+	Message       string       `json:"message"`
+	RequestID     string       `json:"requestId"`
+	RequiredScope string       `json:"requiredScope"`
+	Fields        []FieldError `json:"fields"`
+	Errors        []FieldError `json:"errors"`
+}
+
+// newAPIError reads an error response into an APIError
+func newAPIError(resp *http.Response) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	return parseAPIError(resp, body)
+}
+
+// parseAPIError builds an APIError from a response whose body was already
+// read
+func parseAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+	if after, ok := retryAfter(resp); ok {
+		e.RetryAfter = after
+	}
+
+	var raw struct {
+		apiErrorBody
+		Error json.RawMessage `json:"error"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		// Not JSON; show the text unless it's an HTML error page
+		text := strings.TrimSpace(string(body))
+		if !strings.HasPrefix(text, "<") && len(text) <= 500 {
+			e.Message = text
+		}
+		return e
+	}
+
+	fields := raw.apiErrorBody
+	var message string
+	if len(raw.Error) > 0 && json.Unmarshal(raw.Error, &message) != nil {
+		var nested apiErrorBody
+		if json.Unmarshal(raw.Error, &nested) == nil {
+			fields = nested
+		}
+	}
+
+	e.Code = fields.Code
+	e.Message = fields.Message
+	if message != "" {
+		e.Message = message
+	}
+	e.RequiredScope = fields.RequiredScope
+	e.Fields = append(fields.Fields, fields.Errors...)
+	if fields.RequestID != "" {
+		e.RequestID = fields.RequestID
+	}
+
+	return e
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = strings.ToLower(http.StatusText(e.StatusCode))
+	}
+
+	var b strings.Builder
+	switch {
+	case e.StatusCode == http.StatusUnauthorized:
+		fmt.Fprintf(&b, "authentication failed: %s. Run 'hy auth login' to log in again", msg)
+	case e.StatusCode == http.StatusForbidden:
+		fmt.Fprintf(&b, "permission denied: %s", msg)
+		if e.RequiredScope != "" {
+			fmt.Fprintf(&b, ". The API key needs the %q scope", e.RequiredScope)
+		} else {
+			b.WriteString(". The API key may be missing a required scope; check with 'hy auth status'")
+		}
+	case e.StatusCode == http.StatusNotFound:
+		fmt.Fprintf(&b, "not found: %s", msg)
+	case e.StatusCode == http.StatusConflict:
+		fmt.Fprintf(&b, "conflict: %s", msg)
+	case e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity:
+		fmt.Fprintf(&b, "invalid request: %s", msg)
+		for _, f := range e.Fields {
+			fmt.Fprintf(&b, "\n  - %s: %s", f.Field, f.Message)
+		}
+	case e.StatusCode == http.StatusTooManyRequests:
+		fmt.Fprintf(&b, "rate limited: %s. ", msg)
+		if e.RetryAfter > 0 {
+			fmt.Fprintf(&b, "Try again in %s", e.RetryAfter.Round(time.Second))
+		} else {
+			b.WriteString("Try again later")
+		}
+	case e.StatusCode >= 500:
+		fmt.Fprintf(&b, "server error (%d): %s", e.StatusCode, msg)
+	default:
+		fmt.Fprintf(&b, "API error (%d): %s", e.StatusCode, msg)
+	}
+
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request ID: %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// ExitCode returns the process exit code for an error returned by Execute
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch code := apiErr.StatusCode; {
+		case code == http.StatusUnauthorized || code == http.StatusForbidden:
+			return ExitAuth
+		case code == http.StatusNotFound:
+			return ExitNotFound
+		case code == http.StatusConflict:
+			return ExitConflict
+		case code == http.StatusBadRequest || code == http.StatusUnprocessableEntity:
+			return ExitValidation
+		case code == http.StatusTooManyRequests || code >= 500:
+			return ExitServer
+		}
+		return ExitError
+	}
+
+	if errors.Is(err, errNotAuthenticated) || errors.Is(err, errInvalidKey) {
+		return ExitAuth
+	}
+
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		return ExitUsage
+	}
+
+	var urlErr *url.Error
+	var netErr net.Error
+	if errors.As(err, &urlErr) || errors.As(err, &netErr) {
+		return ExitNetwork
+	}
+
+	return ExitError
+}
+
+// usageError marks invalid command-line flags or arguments
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// usageArgs wraps a cobra argument validator so a wrong number of arguments
+// exits with ExitUsage, like an invalid flag
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return &usageError{err}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func apiErrorFor(status int, header http.Header, body string) *APIError {
+	resp := &http.Response{StatusCode: status, Header: header}
+	if resp.Header == nil {
+		resp.Header = http.Header{}
+	}
+	return parseAPIError(resp, []byte(body))
+}
+
+func TestParseAPIErrorFormats(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want APIError
+	}{
+		{"string error", `{"error": "Production not found"}`, APIError{Message: "Production not found"}},
+		{"flat", `{"code": "invalid_spec", "message": "Spec is invalid", "requestId": "req_1"}`,
+			APIError{Code: "invalid_spec", Message: "Spec is invalid", RequestID: "req_1"}},
+		{"nested", `{"error": {"code": "missing_scope", "message": "Forbidden", "requiredScope": "builds:write"}}`,
+			APIError{Code: "missing_scope", Message: "Forbidden", RequiredScope: "builds:write"}},
+		{"plain text", "upstream connect error", APIError{Message: "upstream connect error"}},
+		{"html page", "<html><body>Bad Gateway</body></html>", APIError{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := apiErrorFor(http.StatusBadRequest, nil, tt.body)
+			if got.Code != tt.want.Code || got.Message != tt.want.Message || got.RequestID != tt.want.RequestID || got.RequiredScope != tt.want.RequiredScope {
+				t.Errorf("Got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIErrorMessages(t *testing.T) {
+	validation := apiErrorFor(http.StatusUnprocessableEntity, http.Header{"X-Request-Id": {"req_hdr"}},
+		`{"message": "Invalid production", "errors": [{"field": "name", "message": "is required"}]}`)
+	AssertContains(t, validation.Error(), "invalid request: Invalid production")
+	AssertContains(t, validation.Error(), "\n  - name: is required")
+	AssertContains(t, validation.Error(), "(request ID: req_hdr)")
+
+	AssertContains(t, apiErrorFor(http.StatusUnauthorized, nil, `{"error": "Invalid API key"}`).Error(), "Run 'hy auth login'")
+	AssertContains(t, apiErrorFor(http.StatusForbidden, nil, `{"error": {"message": "Forbidden", "requiredScope": "keys:write"}}`).Error(), `needs the "keys:write" scope`)
+	AssertContains(t, apiErrorFor(http.StatusForbidden, nil, `{}`).Error(), "permission denied: forbidden")
+	AssertContains(t, apiErrorFor(http.StatusNotFound, nil, `{"error": "Production not found"}`).Error(), "not found: Production not found")
+	AssertContains(t, apiErrorFor(http.StatusTooManyRequests, http.Header{"Retry-After": {"30"}}, `{}`).Error(), "Try again in 30s")
+	AssertContains(t, apiErrorFor(http.StatusInternalServerError, nil, `{"error": "boom"}`).Error(), "server error (500): boom")
+}
+
+func TestExitCode(t *testing.T) {
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+	req, _ := http.NewRequest("GET", unreachable.URL, nil)
+	_, netErr := http.DefaultClient.Do(req)
+
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, 0},
+		{errors.New("something else"), ExitError},
+		{errNotAuthenticated, ExitAuth},
+		{&APIError{StatusCode: http.StatusUnauthorized}, ExitAuth},
+		{&APIError{StatusCode: http.StatusForbidden}, ExitAuth},
+		{fmt.Errorf("failed: %w", &APIError{StatusCode: http.StatusNotFound}), ExitNotFound},
+		{&APIError{StatusCode: http.StatusConflict}, ExitConflict},
+		{&APIError{StatusCode: http.StatusBadRequest}, ExitValidation},
+		{&APIError{StatusCode: http.StatusUnprocessableEntity}, ExitValidation},
+		{&APIError{StatusCode: http.StatusTooManyRequests}, ExitServer},
+		{&APIError{StatusCode: http.StatusBadGateway}, ExitServer},
+		{fmt.Errorf("request failed: %w", netErr), ExitNetwork},
+		{&usageError{errors.New("unknown flag: --bogus")}, ExitUsage},
+	}
+
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestCommandErrorExitCodes(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_missing", http.StatusNotFound, map[string]interface{}{
+		"error": map[string]string{"code": "not_found", "message": "Production not found", "requestId": "req_42"},
+	})
+
+	_, err := ExecuteCommand("productions", "get", "prod_missing")
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+	if ExitCode(err) != ExitNotFound {
+		t.Errorf("Expected exit code %d, got %d", ExitNotFound, ExitCode(err))
+	}
+	if msg := err.Error(); msg != "not found: Production not found (request ID: req_42)" {
+		t.Errorf("Unexpected message %q", msg)
+	}
+
+	_, err = ExecuteCommand("productions", "list", "--bogus")
+	if ExitCode(err) != ExitUsage {
+		t.Errorf("Expected usage exit code for unknown flag, got %d (%v)", ExitCode(err), err)
+	}
+	if !strings.Contains(err.Error(), "unknown flag") {
+		t.Errorf("Unexpected message %q", err)
+	}
+
+	_, err = ExecuteCommand("productions", "get")
+	if ExitCode(err) != ExitUsage {
+		t.Errorf("Expected usage exit code for a missing argument, got %d (%v)", ExitCode(err), err)
+	}
+}
@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// threadExport is the JSON export format
+type threadExport struct {
+	WorkspaceID  string        `json:"workspaceId"`
+	ProductionID string        `json:"productionId,omitempty"`
+	ExportedAt   string        `json:"exportedAt"`
+	Messages     []chatMessage `json:"messages"`
+}
+
+var threadExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the full thread history",
+	Long: `Export the full thread history as Markdown, JSON or HTML, including
+timestamps, roles and suggested changes.
+
+The format defaults to the output file's extension, or Markdown.
+
+Examples:
+  hy thread export -p prod_xxx -o review.md
+  hy thread export -p prod_xxx --format html -o review.html
+  hy thread export --format json | jq '.messages | length'`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		workspaceID := GetWorkspaceID()
+		if workspaceID == "" {
+			return fmt.Errorf("no workspace configured. Run 'hy auth login' first")
+		}
+
+		productionID, _ := cmd.Flags().GetString("production")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		if format == "" {
+			format = exportFormatFor(output)
+		}
+		if format != "md" && format != "json" && format != "html" {
+			return fmt.Errorf("invalid format %q (want md, json or html)", format)
+		}
+
+		session := &chatSession{
+			apiKey:       apiKey,
+			workspaceID:  workspaceID,
+			productionID: productionID,
+			threadID:     selectedThread(cmd, workspaceID, productionID),
+		}
+
+		messages, err := fetchAllThreadMessages(apiKey, session.url())
+		if err != nil {
+			return err
+		}
+
+		export := threadExport{
+			WorkspaceID:  workspaceID,
+			ProductionID: productionID,
+			ExportedAt:   time.Now().UTC().Format(time.RFC3339),
+			Messages:     messages,
+		}
+
+		var data []byte
+		switch format {
+		case "json":
+			data, _ = json.MarshalIndent(export, "", "  ")
+			data = append(data, '\n')
+		case "html":
+			data = []byte(renderExportHTML(export))
+		default:
+			data = []byte(renderExportMarkdown(export))
+		}
+
+		if output == "" || output == "-" {
+			_, err := os.Stdout.Write(data)
+			return err
+		}
+
+		if err := os.WriteFile(output, data, 0644); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+
+		fmt.Printf("✓ Exported %d messages to %s\n", len(messages), output)
+		return nil
+	},
+}
+
+// fetchAllThreadMessages walks every page of a thread and returns the
+// messages oldest first
+func fetchAllThreadMessages(apiKey, threadURL string) ([]chatMessage, error) {
+	var messages []chatMessage
+	cursor := ""
+	seen := make(map[string]bool)
+
+	for {
+		page, err := fetchThreadPage(apiKey, threadURL, 100, cursor)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, page.Messages...)
+
+		if !page.HasMore || page.NextCursor == "" {
+			break
+		}
+		// A cursor seen before would page forever
+		if seen[page.NextCursor] {
+			return nil, fmt.Errorf("the API returned the same page cursor twice; the export would be incomplete")
+		}
+		seen[page.NextCursor] = true
+		cursor = page.NextCursor
+	}
+
+	sortMessagesByTime(messages)
+	return messages, nil
+}
+
+// sortMessagesByTime orders messages by creation time. Timestamps may have
+// any offset and precision, so they're compared as times, not strings;
+// messages without a valid timestamp sort first.
+func sortMessagesByTime(messages []chatMessage) {
+	times := make([]time.Time, len(messages))
+	for i, msg := range messages {
+		times[i], _ = time.Parse(time.RFC3339Nano, msg.CreatedAt)
+	}
+
+	order := make([]int, len(messages))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return times[order[i]].Before(times[order[j]])
+	})
+
+	sorted := make([]chatMessage, len(messages))
+	for i, k := range order {
+		sorted[i] = messages[k]
+	}
+	copy(messages, sorted)
+}
+
+func exportFormatFor(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".html", ".htm":
+		return "html"
+	default:
+		return "md"
+	}
+}
+
+func exportTitle(e threadExport) string {
+	if e.ProductionID != "" {
+		return "Thread: " + e.ProductionID
+	}
+	return "Thread: workspace " + e.WorkspaceID
+}
+
+func roleName(role string) string {
+	return strings.TrimSuffix(rolePrefix(role), ":")
+}
+
+// formatTimestamp shows an RFC 3339 timestamp as "2006-01-02 15:04 UTC"
+func formatTimestamp(ts string) string {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return ts
+	}
+	return t.UTC().Format("2006-01-02 15:04 UTC")
+}
+
+func renderExportMarkdown(e threadExport) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", exportTitle(e))
+	fmt.Fprintf(&b, "_Exported %s · %d messages_\n", formatTimestamp(e.ExportedAt), len(e.Messages))
+
+	for _, msg := range e.Messages {
+		fmt.Fprintf(&b, "\n---\n\n### %s", roleName(msg.Role))
+		if msg.CreatedAt != "" {
+			fmt.Fprintf(&b, " · %s", formatTimestamp(msg.CreatedAt))
+		}
+		fmt.Fprintf(&b, "\n\n%s\n", strings.TrimSpace(msg.Content))
+
+		if len(msg.SuggestedChanges) > 0 {
+			b.WriteString("\n**Suggested changes:**\n\n")
+			for _, c := range msg.SuggestedChanges {
+				fmt.Fprintf(&b, "- %s", c.Description)
+				if c.applicable() {
+					fmt.Fprintf(&b, " (`%s %s`)", valueOrDash(c.Op), c.Path)
+				}
+				b.WriteString("\n")
+			}
+		}
+	}
+
+	return b.String()
+}
+
+const exportHTMLStyle = `body{font-family:-apple-system,BlinkMacSystemFont,"Segoe UI",sans-serif;max-width:760px;margin:2rem auto;padding:0 1rem;color:#1f2328;line-height:1.5}
+.message{border-top:1px solid #d0d7de;padding:1rem 0}
+.meta{color:#59636e;font-size:.875rem}
+.assistant .role{color:#8250df}
+pre{background:#f6f8fa;padding:.75rem;overflow-x:auto}
+code{background:#f6f8fa;padding:.1rem .25rem}
+pre code{padding:0}
+blockquote{border-left:3px solid #d0d7de;margin-left:0;padding-left:1rem;color:#59636e}
+.changes{background:#fff8c5;padding:.5rem 1rem}`
+
+func renderExportHTML(e threadExport) string {
+	var b strings.Builder
+	title := html.EscapeString(exportTitle(e))
+
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n<style>\n%s\n</style>\n</head>\n<body>\n", title, exportHTMLStyle)
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", title)
+	fmt.Fprintf(&b, "<p class=\"meta\">Exported %s · %d messages</p>\n", html.EscapeString(formatTimestamp(e.ExportedAt)), len(e.Messages))
+
+	for _, msg := range e.Messages {
+		fmt.Fprintf(&b, "<section class=\"message %s\" id=\"%s\">\n", html.EscapeString(msg.Role), html.EscapeString(msg.ID))
+		fmt.Fprintf(&b, "<p class=\"meta\"><strong class=\"role\">%s</strong>", html.EscapeString(roleName(msg.Role)))
+		if msg.CreatedAt != "" {
+			fmt.Fprintf(&b, " · <time datetime=\"%s\">%s</time>", html.EscapeString(msg.CreatedAt), html.EscapeString(formatTimestamp(msg.CreatedAt)))
+		}
+		b.WriteString("</p>\n")
+		b.WriteString(markdownToHTML(msg.Content))
+
+		if len(msg.SuggestedChanges) > 0 {
+			b.WriteString("<div class=\"changes\"><strong>Suggested changes:</strong>\n<ul>\n")
+			for _, c := range msg.SuggestedChanges {
+				fmt.Fprintf(&b, "<li>%s", html.EscapeString(c.Description))
+				if c.applicable() {
+					fmt.Fprintf(&b, " <code>%s %s</code>", html.EscapeString(valueOrDash(c.Op)), html.EscapeString(c.Path))
+				}
+				b.WriteString("</li>\n")
+			}
+			b.WriteString("</ul></div>\n")
+		}
+		b.WriteString("</section>\n")
+	}
+
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+func init() {
+	threadCmd.AddCommand(threadExportCmd)
+
+	threadExportCmd.Flags().StringP("production", "p", "", "Production ID")
+	threadExportCmd.Flags().String("thread", "", "Thread ID (default is the thread in use)")
+	threadExportCmd.Flags().String("format", "", "Output format: md, json or html (default from -o extension, else md)")
+	threadExportCmd.Flags().StringP("output", "o", "", "Output file (default stdout)")
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func handlePagedThread(t *testing.T, tc *TestConfig) {
+	tc.Server.Handle("GET", "/workspaces/ws_test123/productions/prod_abc123/thread", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Query().Get("cursor") == "" {
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"messages": []map[string]interface{}{
+					{"id": "msg_1", "role": "user", "content": "Make the hook punchier", "createdAt": "2026-02-06T12:00:00Z"},
+					{"id": "msg_2", "role": "assistant", "content": "Try **this**:\n\n- Open on the product\n- Cut to <b>logo</b>", "createdAt": "2026-02-06T12:00:05Z",
+						"suggestedChanges": []map[string]interface{}{
+							{"description": "Stronger hook", "op": "replace", "path": "/scenes/0/script", "value": "Stop scrolling."},
+						}},
+				},
+				"nextCursor": "page2",
+				"hasMore":    true,
+			})
+			return
+		}
+
+		if r.URL.Query().Get("cursor") != "page2" {
+			t.Errorf("Unexpected cursor %q", r.URL.Query().Get("cursor"))
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"messages": []map[string]interface{}{
+				{"id": "msg_3", "role": "user", "content": "Thanks", "createdAt": "2026-02-06T12:01:00Z"},
+			},
+			"hasMore": false,
+		})
+	})
+}
+
+func TestThreadExportMarkdown(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	handlePagedThread(t, tc)
+
+	output, err := ExecuteCommand("thread", "export", "-p", "prod_abc123")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "# Thread: prod_abc123")
+	AssertContains(t, output, "3 messages")
+	AssertContains(t, output, "### You · 2026-02-06 12:00 UTC")
+	AssertContains(t, output, "### AI · 2026-02-06 12:00 UTC")
+	AssertContains(t, output, "- Stronger hook (`replace /scenes/0/script`)")
+	AssertContains(t, output, "Thanks")
+
+	if strings.Index(output, "punchier") > strings.Index(output, "Thanks") {
+		t.Error("Messages should be in chronological order")
+	}
+}
+
+func TestThreadExportJSONFile(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	handlePagedThread(t, tc)
+
+	path := filepath.Join(tc.ConfigDir, "thread.json")
+	output, err := ExecuteCommand("thread", "export", "-p", "prod_abc123", "-o", path)
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	AssertContains(t, output, "Exported 3 messages")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Export not written: %v", err)
+	}
+
+	var export threadExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		t.Fatalf("Invalid JSON export: %v", err)
+	}
+	if export.ProductionID != "prod_abc123" || len(export.Messages) != 3 {
+		t.Errorf("Unexpected export: %+v", export)
+	}
+	if export.Messages[1].ID != "msg_2" || export.Messages[1].CreatedAt != "2026-02-06T12:00:05Z" {
+		t.Errorf("Expected IDs and timestamps, got %+v", export.Messages[1])
+	}
+	if len(export.Messages[1].SuggestedChanges) != 1 {
+		t.Error("Expected suggested changes in export")
+	}
+}
+
+func TestThreadExportHTML(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	handlePagedThread(t, tc)
+
+	output, err := ExecuteCommand("thread", "export", "-p", "prod_abc123", "--format", "html")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "<!DOCTYPE html>")
+	AssertContains(t, output, "<strong>this</strong>")
+	AssertContains(t, output, "<li>Open on the product</li>")
+	AssertContains(t, output, "&lt;b&gt;logo&lt;/b&gt;")
+	AssertContains(t, output, `<time datetime="2026-02-06T12:00:05Z">`)
+	AssertNotContains(t, output, "<b>logo</b>")
+}
+
+func TestThreadExportInvalidFormat(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	_, err := ExecuteCommand("thread", "export", "--format", "pdf")
+	if err == nil {
+		t.Fatal("Expected error for invalid format")
+	}
+}
+
+func TestSortMessagesByTime(t *testing.T) {
+	messages := []chatMessage{
+		{ID: "msg_b", CreatedAt: "2026-02-06T12:00:00.5Z"},
+		{ID: "msg_c", CreatedAt: "2026-02-06T13:30:00+01:00"}, // 12:30 UTC
+		{ID: "msg_a", CreatedAt: "2026-02-06T12:00:00Z"},
+	}
+
+	sortMessagesByTime(messages)
+
+	if messages[0].ID != "msg_a" || messages[1].ID != "msg_b" || messages[2].ID != "msg_c" {
+		t.Errorf("Unexpected order: %s, %s, %s", messages[0].ID, messages[1].ID, messages[2].ID)
+	}
+}
+
+func TestThreadExportRepeatedCursor(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var cursors []string
+	tc.Server.Handle("GET", "/workspaces/ws_test123/productions/prod_abc123/thread", func(w http.ResponseWriter, r *http.Request) {
+		cursors = append(cursors, r.URL.Query().Get("cursor"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"messages":   []map[string]interface{}{{"role": "user", "content": "Hi"}},
+			"nextCursor": "a+b/c=",
+			"hasMore":    true,
+		})
+	})
+
+	_, err := ExecuteCommand("thread", "export", "-p", "prod_abc123")
+	if err == nil {
+		t.Fatal("Expected error when the cursor repeats")
+	}
+	if len(cursors) != 2 || cursors[1] != "a+b/c=" {
+		t.Errorf("Expected the cursor to be sent escaped once, got %q", cursors)
+	}
+}
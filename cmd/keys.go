@@ -3,11 +3,14 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -20,10 +23,29 @@ var keysCmd = &cobra.Command{
 var keysListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List API keys",
+	Long: `List API keys in the workspace.
+
+Use --unused-since to find stale keys: keys not used within the period,
+including keys created before it that were never used.
+
+Examples:
+  hy keys list
+  hy keys list --unused-since 90d`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
+		}
+
+		unusedSince, _ := cmd.Flags().GetString("unused-since")
+
+		var cutoff time.Time
+		if unusedSince != "" {
+			d, err := parseLongDuration(unusedSince)
+			if err != nil || d <= 0 {
+				return fmt.Errorf("invalid --unused-since %q (e.g. 90d)", unusedSince)
+			}
+			cutoff = time.Now().Add(-d)
 		}
 
 		workspaceID := GetWorkspaceID()
@@ -32,44 +54,55 @@ var keysListCmd = &cobra.Command{
 		req, _ := http.NewRequest("GET", url, nil)
 		req.Header.Set("Authorization", apiKey)
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := doRequest(req)
 		if err != nil {
 			return fmt.Errorf("request failed: %w", err)
 		}
 		defer resp.Body.Close()
 
 		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+			return newAPIError(resp)
 		}
 
 		var result struct {
-			Keys []struct {
-				ID         string   `json:"id"`
-				Name       string   `json:"name"`
-				KeyPrefix  string   `json:"keyPrefix"`
-				Scopes     []string `json:"scopes"`
-				LastUsedAt string   `json:"lastUsedAt"`
-			} `json:"keys"`
+			Keys []apiKeyDetail `json:"keys"`
 		}
 
 		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 			return fmt.Errorf("failed to parse response: %w", err)
 		}
 
-		if len(result.Keys) == 0 {
+		keys := result.Keys
+		if !cutoff.IsZero() {
+			keys = keys[:0]
+			for _, k := range result.Keys {
+				if unusedSinceTime(k, cutoff) {
+					keys = append(keys, k)
+				}
+			}
+		}
+
+		if len(keys) == 0 {
+			if !cutoff.IsZero() {
+				fmt.Printf("No API keys unused since %s\n", cutoff.Format("2006-01-02"))
+				return nil
+			}
 			fmt.Println("No API keys found")
 			return nil
 		}
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "ID\tNAME\tPREFIX\tLAST USED")
-		for _, k := range result.Keys {
+		fmt.Fprintln(w, "ID\tNAME\tPREFIX\tSCOPES\tEXPIRES\tLAST USED")
+		for _, k := range keys {
 			lastUsed := k.LastUsedAt
 			if lastUsed == "" {
 				lastUsed = "never"
 			}
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", k.ID, k.Name, k.KeyPrefix, lastUsed)
+			expires := k.ExpiresAt
+			if expires == "" {
+				expires = "never"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", k.ID, k.Name, k.KeyPrefix, valueOrDash(strings.Join(k.Scopes, ",")), expires, lastUsed)
 		}
 		w.Flush()
 
@@ -77,63 +110,221 @@ var keysListCmd = &cobra.Command{
 	},
 }
 
+var keysGetCmd = &cobra.Command{
+	Use:   "get [key-id]",
+	Short: "Show API key details and recent usage",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		key, err := fetchKey(apiKey, GetWorkspaceID(), args[0])
+		if err != nil {
+			return err
+		}
+
+		lastUsed := key.LastUsedAt
+		if lastUsed == "" {
+			lastUsed = "never"
+		}
+		expires := key.ExpiresAt
+		if expires == "" {
+			expires = "never"
+		}
+
+		fmt.Printf("ID:         %s\n", key.ID)
+		fmt.Printf("Name:       %s\n", key.Name)
+		fmt.Printf("Prefix:     %s\n", valueOrDash(key.KeyPrefix))
+		fmt.Printf("Scopes:     %s\n", valueOrDash(strings.Join(key.Scopes, ", ")))
+		fmt.Printf("Created by: %s\n", valueOrDash(key.CreatedBy))
+		fmt.Printf("Created:    %s\n", valueOrDash(key.CreatedAt))
+		fmt.Printf("Expires:    %s\n", expires)
+		fmt.Printf("Last used:  %s\n", lastUsed)
+
+		if len(key.RecentUsage) > 0 {
+			fmt.Println()
+			fmt.Println("Recent usage:")
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "  TIME\tIP\tENDPOINT\tUSER AGENT")
+			for _, u := range key.RecentUsage {
+				fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", u.At, valueOrDash(u.IP), valueOrDash(u.Endpoint), valueOrDash(u.UserAgent))
+			}
+			w.Flush()
+		}
+
+		return nil
+	},
+}
+
 var keysCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new API key",
+	Long: `Create a new API key.
+
+Scopes are checked against 'hy keys scopes' before the key is created.
+
+Examples:
+  hy keys create --name "CI"
+  hy keys create --name "CI" --scopes productions:read,productions:write --expires-in 30d`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
 		}
 
 		name, _ := cmd.Flags().GetString("name")
 		scopes, _ := cmd.Flags().GetStringSlice("scopes")
+		expiresIn, _ := cmd.Flags().GetString("expires-in")
 
 		if name == "" {
 			return fmt.Errorf("--name is required")
 		}
 
-		workspaceID := GetWorkspaceID()
-		url := fmt.Sprintf("%s/workspaces/%s/keys", GetAPIURL(), workspaceID)
+		expiresAt := ""
+		if expiresIn != "" {
+			d, err := parseLongDuration(expiresIn)
+			if err != nil || d <= 0 {
+				return fmt.Errorf("invalid --expires-in %q (e.g. 30d, 12h)", expiresIn)
+			}
+			expiresAt = time.Now().Add(d).UTC().Format(time.RFC3339)
+		}
 
-		body, _ := json.Marshal(map[string]interface{}{
-			"name":   name,
-			"scopes": scopes,
-		})
+		if err := validateScopes(apiKey, scopes); err != nil {
+			return err
+		}
 
-		req, _ := http.NewRequest("POST", url, bytes.NewReader(body))
-		req.Header.Set("Authorization", apiKey)
-		req.Header.Set("Content-Type", "application/json")
+		result, err := createKey(apiKey, GetWorkspaceID(), name, scopes, expiresAt)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println("✓ API key created")
+		printCreatedKey(result)
+
+		return nil
+	},
+}
+
+var keysRotateCmd = &cobra.Command{
+	Use:   "rotate [key-id]",
+	Short: "Replace an API key with a new one",
+	Long: `Create a replacement key with the same name and scopes, then revoke
+the old key after a grace period so running jobs can switch over.
+
+The old key is set to expire at the end of the grace period; use
+--grace 0 to revoke it immediately. When the old key is the one the CLI
+is logged in with, the new key is stored in its place.
 
-		resp, err := http.DefaultClient.Do(req)
+Examples:
+  hy keys rotate key_abc123
+  hy keys rotate key_abc123 --grace 1h --expires-in 90d`,
+	Args: usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keyID := args[0]
+
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		grace, _ := cmd.Flags().GetString("grace")
+		expiresIn, _ := cmd.Flags().GetString("expires-in")
+
+		gracePeriod, err := parseLongDuration(grace)
+		if err != nil || gracePeriod < 0 {
+			return fmt.Errorf("invalid --grace %q (e.g. 24h, 7d, 0)", grace)
+		}
+
+		workspaceID := GetWorkspaceID()
+
+		old, err := fetchKey(apiKey, workspaceID, keyID)
 		if err != nil {
-			return fmt.Errorf("request failed: %w", err)
+			return err
 		}
-		defer resp.Body.Close()
 
-		if resp.StatusCode != http.StatusCreated {
-			respBody, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+		// Check whether this is the key in use before it's revoked
+		inUse := false
+		if current, err := whoami(apiKey); err == nil {
+			inUse = current.KeyID == keyID
+		} else {
+			fmt.Printf("Warning: could not check whether %s is the key in use: %v\n", keyID, err)
 		}
 
-		var result struct {
-			ID      string `json:"id"`
-			Key     string `json:"key"`
-			Name    string `json:"name"`
-			Warning string `json:"warning"`
+		expiresAt := ""
+		if expiresIn != "" {
+			d, err := parseLongDuration(expiresIn)
+			if err != nil || d <= 0 {
+				return fmt.Errorf("invalid --expires-in %q (e.g. 30d, 12h)", expiresIn)
+			}
+			expiresAt = time.Now().Add(d).UTC().Format(time.RFC3339)
 		}
 
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			return fmt.Errorf("failed to parse response: %w", err)
+		result, err := createKey(apiKey, workspaceID, old.Name, old.Scopes, expiresAt)
+		if err != nil {
+			return fmt.Errorf("failed to create replacement key: %w", err)
 		}
 
-		fmt.Println("✓ API key created")
-		fmt.Println()
-		fmt.Printf("ID:   %s\n", result.ID)
-		fmt.Printf("Name: %s\n", result.Name)
-		fmt.Printf("Key:  %s\n", result.Key)
+		fmt.Printf("✓ Created replacement for %s\n", keyID)
+		printCreatedKey(result)
 		fmt.Println()
-		fmt.Printf("⚠️  %s\n", result.Warning)
+
+		if inUse {
+			if _, source := apiKeySource(); source == "env" {
+				fmt.Println("⚠️  The old key is the one in HY_API_KEY; update it with the new key")
+			} else {
+				if _, err := saveAPIKey(activeProfile(), result.Key, credentialStore()); err != nil {
+					return fmt.Errorf("failed to store the new key: %w", err)
+				}
+				fmt.Println("✓ Stored the new key; the CLI now uses it")
+			}
+			fmt.Println()
+		}
+
+		if gracePeriod == 0 {
+			if err := revokeKey(apiKey, workspaceID, keyID); err != nil {
+				return fmt.Errorf("failed to revoke old key %s: %w", keyID, err)
+			}
+			fmt.Printf("✓ Revoked old key: %s\n", keyID)
+			return nil
+		}
+
+		revokeAt := time.Now().Add(gracePeriod).UTC()
+		if t, err := time.Parse(time.RFC3339, old.ExpiresAt); err == nil && t.Before(revokeAt) {
+			fmt.Printf("Old key %s already expires at %s\n", keyID, old.ExpiresAt)
+			return nil
+		}
+
+		if err := expireKey(apiKey, workspaceID, keyID, revokeAt.Format(time.RFC3339)); err != nil {
+			return fmt.Errorf("failed to schedule revocation of old key %s: %w", keyID, err)
+		}
+		fmt.Printf("✓ Old key %s will stop working at %s\n", keyID, revokeAt.Format(time.RFC3339))
+
+		return nil
+	},
+}
+
+var keysScopesCmd = &cobra.Command{
+	Use:   "scopes",
+	Short: "List valid API key scopes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		scopes, err := fetchScopes(apiKey)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "SCOPE\tDESCRIPTION")
+		for _, s := range scopes {
+			fmt.Fprintf(w, "%s\t%s\n", s.Name, valueOrDash(s.Description))
+		}
+		w.Flush()
 
 		return nil
 	},
@@ -142,42 +333,27 @@ var keysCreateCmd = &cobra.Command{
 var keysRevokeCmd = &cobra.Command{
 	Use:   "revoke [key-id]",
 	Short: "Revoke an API key",
-	Args:  cobra.ExactArgs(1),
+	Args:  usageArgs(cobra.ExactArgs(1)),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		keyID := args[0]
 
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
 		}
 
 		// Confirm unless --force
 		force, _ := cmd.Flags().GetBool("force")
 		if !force {
 			fmt.Printf("Revoke API key %s? This cannot be undone. [y/N] ", keyID)
-			var confirm string
-			fmt.Scanln(&confirm)
-			if confirm != "y" && confirm != "Y" && confirm != "yes" {
+			if !confirmPrompt() {
 				fmt.Println("Cancelled")
 				return nil
 			}
 		}
 
-		workspaceID := GetWorkspaceID()
-		url := fmt.Sprintf("%s/workspaces/%s/keys/%s", GetAPIURL(), workspaceID, keyID)
-
-		req, _ := http.NewRequest("DELETE", url, nil)
-		req.Header.Set("Authorization", apiKey)
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return fmt.Errorf("request failed: %w", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+		if err := revokeKey(apiKey, GetWorkspaceID(), keyID); err != nil {
+			return err
 		}
 
 		fmt.Printf("✓ Revoked API key: %s\n", keyID)
@@ -185,13 +361,272 @@ var keysRevokeCmd = &cobra.Command{
 	},
 }
 
+// revokeKey deletes an API key on the server
+func revokeKey(apiKey, workspaceID, keyID string) error {
+	url := fmt.Sprintf("%s/workspaces/%s/keys/%s", GetAPIURL(), workspaceID, keyID)
+
+	req, _ := http.NewRequest("DELETE", url, nil)
+	req.Header.Set("Authorization", apiKey)
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
+
+type scopeInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// createdKey is the response to creating a key; Key is only returned once
+type createdKey struct {
+	ID        string   `json:"id"`
+	Key       string   `json:"key"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expiresAt"`
+	Warning   string   `json:"warning"`
+}
+
+// apiKeyDetail is a key as returned by the list and get endpoints. Only get
+// includes recent usage.
+type apiKeyDetail struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	KeyPrefix   string     `json:"keyPrefix"`
+	Scopes      []string   `json:"scopes"`
+	CreatedBy   string     `json:"createdBy"`
+	CreatedAt   string     `json:"createdAt"`
+	ExpiresAt   string     `json:"expiresAt"`
+	LastUsedAt  string     `json:"lastUsedAt"`
+	RecentUsage []keyUsage `json:"recentUsage"`
+}
+
+// keyUsage is one recorded request made with a key
+type keyUsage struct {
+	At        string `json:"at"`
+	IP        string `json:"ip"`
+	UserAgent string `json:"userAgent"`
+	Endpoint  string `json:"endpoint"`
+}
+
+// unusedSinceTime reports whether k hasn't been used since cutoff. Keys that
+// were never used count once they are older than cutoff.
+func unusedSinceTime(k apiKeyDetail, cutoff time.Time) bool {
+	ts := k.LastUsedAt
+	if ts == "" {
+		ts = k.CreatedAt
+	}
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		// Unknown age: show it rather than hide a possibly stale key
+		return true
+	}
+	return t.Before(cutoff)
+}
+
+func createKey(apiKey, workspaceID, name string, scopes []string, expiresAt string) (*createdKey, error) {
+	url := fmt.Sprintf("%s/workspaces/%s/keys", GetAPIURL(), workspaceID)
+
+	payload := map[string]interface{}{
+		"name":   name,
+		"scopes": scopes,
+	}
+	if expiresAt != "" {
+		payload["expiresAt"] = expiresAt
+	}
+	body, _ := json.Marshal(payload)
+
+	req, _ := http.NewRequest("POST", url, bytes.NewReader(body))
+	req.Header.Set("Authorization", apiKey)
+	setIdempotencyKey(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return nil, newAPIError(resp)
+	}
+
+	var result createdKey
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
+
+func printCreatedKey(result *createdKey) {
+	fmt.Println()
+	fmt.Printf("ID:      %s\n", result.ID)
+	fmt.Printf("Name:    %s\n", result.Name)
+	if len(result.Scopes) > 0 {
+		fmt.Printf("Scopes:  %s\n", strings.Join(result.Scopes, ", "))
+	}
+	if result.ExpiresAt != "" {
+		fmt.Printf("Expires: %s\n", result.ExpiresAt)
+	}
+	fmt.Printf("Key:     %s\n", result.Key)
+	if result.Warning != "" {
+		fmt.Println()
+		fmt.Printf("⚠️  %s\n", result.Warning)
+	}
+}
+
+func fetchKey(apiKey, workspaceID, keyID string) (*apiKeyDetail, error) {
+	url := fmt.Sprintf("%s/workspaces/%s/keys/%s", GetAPIURL(), workspaceID, keyID)
+
+	req, _ := http.NewRequest("GET", url, nil)
+	req.Header.Set("Authorization", apiKey)
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		apiErr := newAPIError(resp)
+		apiErr.Message = "API key " + keyID
+		return nil, apiErr
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result apiKeyDetail
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// expireKey sets a key's expiry so the server stops accepting it at expiresAt
+func expireKey(apiKey, workspaceID, keyID, expiresAt string) error {
+	url := fmt.Sprintf("%s/workspaces/%s/keys/%s", GetAPIURL(), workspaceID, keyID)
+
+	body, _ := json.Marshal(map[string]string{"expiresAt": expiresAt})
+
+	req, _ := http.NewRequest("PATCH", url, bytes.NewReader(body))
+	req.Header.Set("Authorization", apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
+
+// errScopesUnavailable means the API doesn't publish its scope list
+var errScopesUnavailable = errors.New("this API does not list key scopes")
+
+func fetchScopes(apiKey string) ([]scopeInfo, error) {
+	req, _ := http.NewRequest("GET", GetAPIURL()+"/auth/scopes", nil)
+	req.Header.Set("Authorization", apiKey)
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errScopesUnavailable
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result struct {
+		Scopes []scopeInfo `json:"scopes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return result.Scopes, nil
+}
+
+// validateScopes rejects scopes the API doesn't know about. Older API
+// versions without a scope list are not validated.
+func validateScopes(apiKey string, scopes []string) error {
+	valid, err := fetchScopes(apiKey)
+	if errors.Is(err, errScopesUnavailable) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch valid scopes: %w", err)
+	}
+
+	known := make(map[string]bool, len(valid))
+	for _, s := range valid {
+		known[s.Name] = true
+	}
+
+	var unknown []string
+	for _, s := range scopes {
+		if !known[s] {
+			unknown = append(unknown, s)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown scope(s): %s. Run 'hy keys scopes' to list valid scopes", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// parseLongDuration parses durations like time.ParseDuration, plus day (d)
+// and week (w) units, e.g. 30d or 2w
+func parseLongDuration(s string) (time.Duration, error) {
+	if s == "0" {
+		return 0, nil
+	}
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(v) * unit, nil
+		}
+	}
+	return time.ParseDuration(s)
+}
+
 func init() {
 	rootCmd.AddCommand(keysCmd)
 	keysCmd.AddCommand(keysListCmd)
+	keysCmd.AddCommand(keysGetCmd)
 	keysCmd.AddCommand(keysCreateCmd)
 	keysCmd.AddCommand(keysRevokeCmd)
+	keysCmd.AddCommand(keysRotateCmd)
+	keysCmd.AddCommand(keysScopesCmd)
 
+	keysListCmd.Flags().String("unused-since", "", "Only show keys not used within this period (e.g. 90d)")
 	keysCreateCmd.Flags().String("name", "", "Key name (required)")
-	keysCreateCmd.Flags().StringSlice("scopes", []string{"productions:read", "assets:read"}, "Key scopes")
+	keysCreateCmd.Flags().StringSlice("scopes", []string{"productions:read", "assets:read"}, "Key scopes (see 'hy keys scopes')")
+	keysCreateCmd.Flags().String("expires-in", "", "Expire the key after this long (e.g. 30d, 12h)")
+	keysRotateCmd.Flags().String("grace", "24h", "How long the old key keeps working (e.g. 1h, 7d, 0)")
+	keysRotateCmd.Flags().String("expires-in", "", "Expire the new key after this long (e.g. 90d)")
 	keysRevokeCmd.Flags().Bool("force", false, "Skip confirmation prompt")
 }
@@ -5,6 +5,9 @@ import (
 	"io"
 	"net/http"
 	"testing"
+	"time"
+
+	"github.com/zalando/go-keyring"
 )
 
 func TestKeysList(t *testing.T) {
@@ -94,6 +97,32 @@ func TestKeysRevokeForce(t *testing.T) {
 	AssertContains(t, output, "Revoked")
 }
 
+func TestKeysRevokeConfirm(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	revoked := false
+	tc.Server.Handle("DELETE", "/workspaces/ws_test123/keys/key_abc123", func(w http.ResponseWriter, r *http.Request) {
+		revoked = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	output := CaptureOutput(func() {
+		ExecuteCommandWithStdin("n\n", "keys", "revoke", "key_abc123")
+	})
+	AssertContains(t, output, "Cancelled")
+	if revoked {
+		t.Fatal("Key was revoked without confirmation")
+	}
+
+	CaptureOutput(func() {
+		ExecuteCommandWithStdin("YES\n", "keys", "revoke", "key_abc123")
+	})
+	if !revoked {
+		t.Error("Expected the key to be revoked after confirming")
+	}
+}
+
 func TestKeysListEmpty(t *testing.T) {
 	tc := SetupTest(t)
 	defer tc.Cleanup()
@@ -119,3 +148,279 @@ func TestKeysCreateMissingName(t *testing.T) {
 		t.Error("Expected error for missing name")
 	}
 }
+
+var testScopes = map[string]interface{}{
+	"scopes": []map[string]string{
+		{"name": "productions:read", "description": "Read productions"},
+		{"name": "productions:write", "description": "Create and build productions"},
+		{"name": "assets:read", "description": "Read assets"},
+	},
+}
+
+func TestKeysScopes(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/auth/scopes", http.StatusOK, testScopes)
+
+	output, err := ExecuteCommand("keys", "scopes")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "productions:write")
+	AssertContains(t, output, "Create and build productions")
+}
+
+func TestKeysCreateUnknownScope(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/auth/scopes", http.StatusOK, testScopes)
+	tc.Server.Handle("POST", "/workspaces/ws_test123/keys", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Key should not be created with unknown scopes")
+	})
+
+	_, err := ExecuteCommand("keys", "create", "--name", "CI", "--scopes", "productions:read,production:write")
+	if err == nil {
+		t.Fatal("Expected error for unknown scope")
+	}
+	AssertContains(t, err.Error(), "production:write")
+}
+
+func TestKeysCreateExpiresIn(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var receivedBody map[string]interface{}
+
+	tc.Server.HandleJSON("GET", "/auth/scopes", http.StatusOK, testScopes)
+	tc.Server.Handle("POST", "/workspaces/ws_test123/keys", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &receivedBody)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":        "key_new123",
+			"key":       "sk_live_full_key_value_here",
+			"name":      receivedBody["name"],
+			"expiresAt": receivedBody["expiresAt"],
+		})
+	})
+
+	output, err := ExecuteCommand("keys", "create", "--name", "CI", "--expires-in", "30d")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, receivedBody["expiresAt"].(string))
+	if err != nil {
+		t.Fatalf("Expected RFC 3339 expiresAt, got %v", receivedBody["expiresAt"])
+	}
+	if d := time.Until(expiresAt); d < 29*24*time.Hour || d > 31*24*time.Hour {
+		t.Errorf("Expected expiry in 30 days, got %s", d)
+	}
+	AssertContains(t, output, "Expires:")
+}
+
+func TestKeysRotate(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var created, patched map[string]interface{}
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/keys/key_old", http.StatusOK, map[string]interface{}{
+		"id":     "key_old",
+		"name":   "CI",
+		"scopes": []string{"productions:read", "productions:write"},
+	})
+	tc.Server.Handle("POST", "/workspaces/ws_test123/keys", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &created)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":     "key_new",
+			"key":    "sk_live_rotated_key_value",
+			"name":   created["name"],
+			"scopes": created["scopes"],
+		})
+	})
+	tc.Server.Handle("PATCH", "/workspaces/ws_test123/keys/key_old", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &patched)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	output, err := ExecuteCommand("keys", "rotate", "key_old", "--grace", "1h")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if created["name"] != "CI" {
+		t.Errorf("Expected replacement named CI, got %v", created["name"])
+	}
+	if scopes, _ := created["scopes"].([]interface{}); len(scopes) != 2 || scopes[1] != "productions:write" {
+		t.Errorf("Expected identical scopes, got %v", created["scopes"])
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, patched["expiresAt"].(string))
+	if err != nil {
+		t.Fatalf("Expected old key expiry to be set, got %v", patched)
+	}
+	if d := time.Until(expiresAt); d < 50*time.Minute || d > 70*time.Minute {
+		t.Errorf("Expected old key to expire in 1h, got %s", d)
+	}
+
+	AssertContains(t, output, "sk_live_rotated_key_value")
+	AssertContains(t, output, "Old key key_old will stop working")
+}
+
+func TestKeysRotateNoGrace(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	revoked := false
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/keys/key_old", http.StatusOK, map[string]interface{}{
+		"id":     "key_old",
+		"name":   "CI",
+		"scopes": []string{"productions:read"},
+	})
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/keys", http.StatusCreated, map[string]interface{}{
+		"id":  "key_new",
+		"key": "sk_live_rotated_key_value",
+	})
+	tc.Server.Handle("DELETE", "/workspaces/ws_test123/keys/key_old", func(w http.ResponseWriter, r *http.Request) {
+		revoked = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	output, err := ExecuteCommand("keys", "rotate", "key_old", "--grace", "0")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if !revoked {
+		t.Error("Expected old key to be revoked immediately")
+	}
+	AssertContains(t, output, "Revoked old key: key_old")
+}
+
+func TestKeysRotateKeyInUse(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/auth/whoami", http.StatusOK, map[string]interface{}{
+		"keyId":       "key_old",
+		"workspaceId": "ws_test123",
+	})
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/keys/key_old", http.StatusOK, map[string]interface{}{
+		"id":   "key_old",
+		"name": "Laptop",
+	})
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/keys", http.StatusCreated, map[string]interface{}{
+		"id":  "key_new",
+		"key": "sk_live_rotated_key_value",
+	})
+	tc.Server.HandleJSON("DELETE", "/workspaces/ws_test123/keys/key_old", http.StatusOK, map[string]interface{}{})
+
+	output, err := ExecuteCommand("keys", "rotate", "key_old", "--grace", "0")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if key, _ := keyring.Get(serviceName, keyringUser); key != "sk_live_rotated_key_value" {
+		t.Errorf("Expected the new key to be stored, got %q", key)
+	}
+	AssertContains(t, output, "Stored the new key")
+}
+
+func TestParseLongDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"0":   0,
+		"30d": 30 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"12h": 12 * time.Hour,
+	}
+
+	for in, want := range tests {
+		got, err := parseLongDuration(in)
+		if err != nil || got != want {
+			t.Errorf("parseLongDuration(%q) = %s, %v; want %s", in, got, err, want)
+		}
+	}
+
+	if _, err := parseLongDuration("xd"); err == nil {
+		t.Error("Expected error for invalid duration")
+	}
+}
+
+func TestKeysGet(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/keys/key_abc123", http.StatusOK, map[string]interface{}{
+		"id":         "key_abc123",
+		"name":       "CI",
+		"keyPrefix":  "sk_live_xxxx",
+		"scopes":     []string{"productions:read", "assets:read"},
+		"createdBy":  "dana@example.com",
+		"createdAt":  "2026-02-06T12:00:00Z",
+		"lastUsedAt": "2026-03-01T09:30:00Z",
+		"recentUsage": []map[string]string{
+			{"at": "2026-03-01T09:30:00Z", "ip": "203.0.113.7", "userAgent": "hy/1.2.0", "endpoint": "GET /workspaces/ws_test123/productions"},
+		},
+	})
+
+	output, err := ExecuteCommand("keys", "get", "key_abc123")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "productions:read, assets:read")
+	AssertContains(t, output, "dana@example.com")
+	AssertContains(t, output, "2026-02-06T12:00:00Z")
+	AssertContains(t, output, "Recent usage:")
+	AssertContains(t, output, "203.0.113.7")
+	AssertContains(t, output, "hy/1.2.0")
+	AssertContains(t, output, "GET /workspaces/ws_test123/productions")
+}
+
+func TestKeysGetNotFound(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	_, err := ExecuteCommand("keys", "get", "key_missing")
+	if err == nil {
+		t.Fatal("Expected error for missing key")
+	}
+	AssertContains(t, err.Error(), "not found")
+}
+
+func TestKeysListUnusedSince(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	now := time.Now().UTC()
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/keys", http.StatusOK, KeysListResponse{
+		Keys: []KeyResponse{
+			{ID: "key_recent", CreatedAt: now.AddDate(-1, 0, 0).Format(time.RFC3339), LastUsedAt: now.AddDate(0, 0, -5).Format(time.RFC3339)},
+			{ID: "key_stale", CreatedAt: now.AddDate(-1, 0, 0).Format(time.RFC3339), LastUsedAt: now.AddDate(0, -6, 0).Format(time.RFC3339)},
+			{ID: "key_never_old", CreatedAt: now.AddDate(0, -4, 0).Format(time.RFC3339)},
+			{ID: "key_never_new", CreatedAt: now.AddDate(0, 0, -1).Format(time.RFC3339)},
+		},
+	})
+
+	output, err := ExecuteCommand("keys", "list", "--unused-since", "90d")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "key_stale")
+	AssertContains(t, output, "key_never_old")
+	AssertNotContains(t, output, "key_recent")
+	AssertNotContains(t, output, "key_never_new")
+}
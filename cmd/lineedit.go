@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+)
+
+// errInterrupted is returned by a line reader when Ctrl+C cancels the line
+var errInterrupted = errors.New("interrupted")
+
+// lineReader reads lines of input for the chat REPL
+type lineReader interface {
+	ReadLine(prompt string) (string, error)
+	SetHistory(history []string)
+	AddHistory(line string)
+}
+
+// newLineReader returns a line editor with history when stdin and stdout
+// are terminals, otherwise a plain reader. Neither limits the line length.
+func newLineReader() lineReader {
+	if term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd())) {
+		return &terminalReader{in: bufio.NewReader(os.Stdin), out: os.Stdout}
+	}
+	return &plainReader{in: bufio.NewReader(os.Stdin)}
+}
+
+// plainReader reads lines without editing, e.g. from a pipe
+type plainReader struct {
+	in *bufio.Reader
+}
+
+func (r *plainReader) ReadLine(prompt string) (string, error) {
+	fmt.Print(prompt)
+	line, err := r.in.ReadString('\n')
+	if err != nil && (line == "" || !errors.Is(err, io.EOF)) {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (r *plainReader) SetHistory(history []string) {}
+
+func (r *plainReader) AddHistory(line string) {}
+
+// terminalReader is a minimal emacs-style line editor: arrow keys, Home/End,
+// Ctrl+A/E/B/F/K/U/W/L, Up/Down through history, and bracketed paste
+type terminalReader struct {
+	in      *bufio.Reader
+	out     io.Writer
+	history []string
+}
+
+func (r *terminalReader) SetHistory(history []string) {
+	r.history = append([]string(nil), history...)
+}
+
+func (r *terminalReader) AddHistory(line string) {
+	if line != "" && (len(r.history) == 0 || r.history[len(r.history)-1] != line) {
+		r.history = append(r.history, line)
+	}
+}
+
+func (r *terminalReader) ReadLine(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return (&plainReader{in: r.in}).ReadLine(prompt)
+	}
+	defer term.Restore(fd, state)
+
+	// Bracketed paste marks pasted text so its newlines aren't taken as Enter
+	fmt.Fprint(r.out, "\033[?2004h")
+	defer fmt.Fprint(r.out, "\033[?2004l")
+
+	return editLine(r.in, r.out, prompt, r.history, terminalWidth(os.Stdout))
+}
+
+// editLine runs the editor over keys read from in, echoing to out. A line
+// longer than width columns scrolls horizontally; width 0 means unknown.
+func editLine(in *bufio.Reader, out io.Writer, prompt string, history []string, width int) (string, error) {
+	var line []rune
+	pos := 0
+
+	// Index into history; len(history) is the line being edited
+	hist := len(history)
+	draft := ""
+
+	// First rune shown when the line is scrolled
+	offset := 0
+	pasting := false
+	var prev rune
+
+	redraw := func() {
+		view, cursor := line, pos
+		avail := width - utf8.RuneCountInString(prompt) - 1
+		if width > 0 && avail > 0 && len(line) > avail {
+			if pos < offset {
+				offset = pos
+			} else if pos-offset > avail {
+				offset = pos - avail
+			}
+			offset = min(offset, len(line)-avail)
+			view, cursor = line[offset:offset+avail], pos-offset
+		} else {
+			offset = 0
+		}
+
+		// Pasted newlines are shown as a symbol to keep the line on one row
+		shown := strings.ReplaceAll(string(view), "\n", "↵")
+		fmt.Fprintf(out, "\r%s%s\033[K", prompt, shown)
+		if back := len(view) - cursor; back > 0 {
+			fmt.Fprintf(out, "\033[%dD", back)
+		}
+	}
+	insert := func(c rune) {
+		line = append(line[:pos], append([]rune{c}, line[pos:]...)...)
+		pos++
+		redraw()
+	}
+	setLine := func(s string) {
+		line = []rune(s)
+		pos = len(line)
+		redraw()
+	}
+
+	fmt.Fprint(out, prompt)
+
+	for {
+		c, _, err := in.ReadRune()
+		if err != nil {
+			if len(line) > 0 && errors.Is(err, io.EOF) {
+				fmt.Fprint(out, "\r\n")
+				return string(line), nil
+			}
+			return "", err
+		}
+		last := prev
+		prev = c
+
+		if pasting {
+			switch {
+			case c == 27:
+				if readEscape(in) == "paste-end" {
+					pasting = false
+				}
+			case c == '\n' && last == '\r':
+				// Second half of a pasted CRLF
+			case c == '\r' || c == '\n':
+				insert('\n')
+			case c == '\t' || (c >= 32 && c != 127 && c != utf8.RuneError):
+				insert(c)
+			}
+			continue
+		}
+
+		switch c {
+		case '\r', '\n':
+			fmt.Fprint(out, "\r\n")
+			return string(line), nil
+		case 3: // Ctrl+C
+			fmt.Fprint(out, "^C\r\n")
+			return "", errInterrupted
+		case 4: // Ctrl+D
+			if len(line) == 0 {
+				fmt.Fprint(out, "\r\n")
+				return "", io.EOF
+			}
+			if pos < len(line) {
+				line = append(line[:pos], line[pos+1:]...)
+				redraw()
+			}
+		case 127, 8: // Backspace
+			if pos > 0 {
+				line = append(line[:pos-1], line[pos:]...)
+				pos--
+				redraw()
+			}
+		case 1: // Ctrl+A
+			pos = 0
+			redraw()
+		case 5: // Ctrl+E
+			pos = len(line)
+			redraw()
+		case 2: // Ctrl+B
+			if pos > 0 {
+				pos--
+				redraw()
+			}
+		case 6: // Ctrl+F
+			if pos < len(line) {
+				pos++
+				redraw()
+			}
+		case 11: // Ctrl+K
+			line = line[:pos]
+			redraw()
+		case 21: // Ctrl+U
+			line = line[pos:]
+			pos = 0
+			redraw()
+		case 23: // Ctrl+W
+			start := pos
+			for start > 0 && line[start-1] == ' ' {
+				start--
+			}
+			for start > 0 && line[start-1] != ' ' {
+				start--
+			}
+			line = append(line[:start], line[pos:]...)
+			pos = start
+			redraw()
+		case 12: // Ctrl+L
+			fmt.Fprint(out, "\033[H\033[2J")
+			redraw()
+		case 27: // Escape sequence
+			key := readEscape(in)
+			switch key {
+			case "up":
+				if hist > 0 {
+					if hist == len(history) {
+						draft = string(line)
+					}
+					hist--
+					setLine(history[hist])
+				}
+			case "down":
+				if hist < len(history) {
+					hist++
+					if hist == len(history) {
+						setLine(draft)
+					} else {
+						setLine(history[hist])
+					}
+				}
+			case "left":
+				if pos > 0 {
+					pos--
+					redraw()
+				}
+			case "right":
+				if pos < len(line) {
+					pos++
+					redraw()
+				}
+			case "home":
+				pos = 0
+				redraw()
+			case "end":
+				pos = len(line)
+				redraw()
+			case "delete":
+				if pos < len(line) {
+					line = append(line[:pos], line[pos+1:]...)
+					redraw()
+				}
+			case "paste-start":
+				pasting = true
+			}
+		default:
+			if c == utf8.RuneError || c < 32 {
+				continue
+			}
+			insert(c)
+		}
+	}
+}
+
+// readEscape decodes the rest of an ANSI escape sequence after ESC
+func readEscape(in *bufio.Reader) string {
+	b, err := in.ReadByte()
+	if err != nil || (b != '[' && b != 'O') {
+		return ""
+	}
+
+	var seq []byte
+	for {
+		c, err := in.ReadByte()
+		if err != nil {
+			return ""
+		}
+		seq = append(seq, c)
+		if c >= 0x40 && c <= 0x7e {
+			break
+		}
+	}
+
+	switch string(seq) {
+	case "A":
+		return "up"
+	case "B":
+		return "down"
+	case "C":
+		return "right"
+	case "D":
+		return "left"
+	case "H", "1~", "7~":
+		return "home"
+	case "F", "4~", "8~":
+		return "end"
+	case "3~":
+		return "delete"
+	case "200~":
+		return "paste-start"
+	case "201~":
+		return "paste-end"
+	}
+	return ""
+}
@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// mdBlock is a block-level Markdown element. This is a small subset of
+// CommonMark covering what the assistant writes: headings, paragraphs,
+// lists, block quotes, fenced code and rules.
+type mdBlock struct {
+	kind    string // heading, paragraph, list, quote, code, rule, table
+	level   int    // heading level
+	ordered bool   // numbered list
+	start   int    // number of the first item in a numbered list
+	lang    string // code block language
+	lines   []string
+}
+
+// mdSpan is an inline Markdown element
+type mdSpan struct {
+	kind string // text, bold, italic, code, link
+	text string
+	url  string
+}
+
+var (
+	mdHeading   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	mdBullet    = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	mdNumbered  = regexp.MustCompile(`^\s*(\d+)[.)]\s+(.*)$`)
+	mdTableSep  = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdRule      = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	mdInlineTok = regexp.MustCompile("`[^`]+`|\\*\\*[^*]+\\*\\*|__[^_]+__|\\*[^*\\s][^*]*\\*|_[^_\\s][^_]*_|\\[[^\\]]+\\]\\([^)\\s]+\\)")
+)
+
+// parseMarkdown splits Markdown source into blocks
+func parseMarkdown(src string) []mdBlock {
+	var blocks []mdBlock
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			continue
+
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			fence := trimmed[:3]
+			block := mdBlock{kind: "code", lang: strings.TrimSpace(trimmed[3:])}
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				block.lines = append(block.lines, lines[i])
+			}
+			blocks = append(blocks, block)
+
+		case mdHeading.MatchString(trimmed):
+			m := mdHeading.FindStringSubmatch(trimmed)
+			blocks = append(blocks, mdBlock{kind: "heading", level: len(m[1]), lines: []string{m[2]}})
+
+		case isTableStart(lines, i):
+			block := mdBlock{kind: "table", lines: []string{line}}
+			for i += 2; i < len(lines) && strings.Contains(lines[i], "|"); i++ {
+				block.lines = append(block.lines, lines[i])
+			}
+			i--
+			blocks = append(blocks, block)
+
+		case mdRule.MatchString(trimmed):
+			blocks = append(blocks, mdBlock{kind: "rule"})
+
+		case mdBullet.MatchString(line) || mdNumbered.MatchString(line):
+			ordered := !mdBullet.MatchString(line)
+			item := mdBullet
+			if ordered {
+				item = mdNumbered
+			}
+			block := mdBlock{kind: "list", ordered: ordered}
+			if ordered {
+				block.start, _ = strconv.Atoi(mdNumbered.FindStringSubmatch(line)[1])
+			}
+			for ; i < len(lines); i++ {
+				if m := item.FindStringSubmatch(lines[i]); m != nil {
+					block.lines = append(block.lines, m[len(m)-1])
+				} else if strings.TrimSpace(lines[i]) != "" && len(block.lines) > 0 && strings.HasPrefix(lines[i], " ") {
+					// Indented continuation of the previous item
+					block.lines[len(block.lines)-1] += " " + strings.TrimSpace(lines[i])
+				} else {
+					break
+				}
+			}
+			i--
+			blocks = append(blocks, block)
+
+		case strings.HasPrefix(trimmed, ">"):
+			block := mdBlock{kind: "quote"}
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				text := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				block.lines = append(block.lines, strings.TrimPrefix(text, " "))
+			}
+			i--
+			blocks = append(blocks, block)
+
+		default:
+			block := mdBlock{kind: "paragraph"}
+			for ; i < len(lines); i++ {
+				t := strings.TrimSpace(lines[i])
+				if t == "" || startsBlock(lines[i]) || isTableStart(lines, i) {
+					break
+				}
+				block.lines = append(block.lines, t)
+			}
+			i--
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks
+}
+
+// isTableStart reports whether lines[i] is the header row of a table, i.e.
+// it's followed by a delimiter row such as "|---|:--:|"
+func isTableStart(lines []string, i int) bool {
+	return strings.Contains(lines[i], "|") && i+1 < len(lines) && mdTableSep.MatchString(lines[i+1])
+}
+
+// splitTableRow splits a table row into trimmed cells
+func splitTableRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	row = strings.TrimSuffix(row, "|")
+
+	cells := strings.Split(row, "|")
+	for i := range cells {
+		cells[i] = strings.TrimSpace(cells[i])
+	}
+	return cells
+}
+
+// startsBlock reports whether line begins a block other than a paragraph
+func startsBlock(line string) bool {
+	t := strings.TrimSpace(line)
+	return strings.HasPrefix(t, "```") || strings.HasPrefix(t, "~~~") || strings.HasPrefix(t, ">") ||
+		mdHeading.MatchString(t) || mdRule.MatchString(t) || mdBullet.MatchString(line) || mdNumbered.MatchString(line)
+}
+
+// parseInline splits text into plain and emphasized spans
+func parseInline(text string) []mdSpan {
+	var spans []mdSpan
+	last := 0
+
+	for _, loc := range mdInlineTok.FindAllStringIndex(text, -1) {
+		if loc[0] > last {
+			spans = append(spans, mdSpan{kind: "text", text: text[last:loc[0]]})
+		}
+		tok := text[loc[0]:loc[1]]
+		switch {
+		case strings.HasPrefix(tok, "`"):
+			spans = append(spans, mdSpan{kind: "code", text: tok[1 : len(tok)-1]})
+		case strings.HasPrefix(tok, "**"), strings.HasPrefix(tok, "__"):
+			spans = append(spans, mdSpan{kind: "bold", text: tok[2 : len(tok)-2]})
+		case strings.HasPrefix(tok, "["):
+			label, url, _ := strings.Cut(tok[1:len(tok)-1], "](")
+			spans = append(spans, mdSpan{kind: "link", text: label, url: url})
+		default:
+			spans = append(spans, mdSpan{kind: "italic", text: tok[1 : len(tok)-1]})
+		}
+		last = loc[1]
+	}
+	if last < len(text) {
+		spans = append(spans, mdSpan{kind: "text", text: text[last:]})
+	}
+
+	return spans
+}
+
+// markdownToHTML renders Markdown as HTML, escaping all text
+func markdownToHTML(src string) string {
+	var b strings.Builder
+
+	for _, block := range parseMarkdown(src) {
+		switch block.kind {
+		case "heading":
+			tag := "h" + string(rune('0'+block.level))
+			b.WriteString("<" + tag + ">" + inlineHTML(block.lines[0]) + "</" + tag + ">\n")
+		case "paragraph":
+			b.WriteString("<p>" + inlineHTML(strings.Join(block.lines, "\n")) + "</p>\n")
+		case "list":
+			tag, open := "ul", "<ul>"
+			if block.ordered {
+				tag, open = "ol", "<ol>"
+				if block.start != 1 {
+					open = fmt.Sprintf(`<ol start="%d">`, block.start)
+				}
+			}
+			b.WriteString(open + "\n")
+			for _, item := range block.lines {
+				b.WriteString("<li>" + inlineHTML(item) + "</li>\n")
+			}
+			b.WriteString("</" + tag + ">\n")
+		case "quote":
+			b.WriteString("<blockquote>" + markdownToHTML(strings.Join(block.lines, "\n")) + "</blockquote>\n")
+		case "code":
+			b.WriteString("<pre><code>" + html.EscapeString(strings.Join(block.lines, "\n")) + "</code></pre>\n")
+		case "table":
+			b.WriteString("<table>\n")
+			for i, row := range block.lines {
+				cell := "td"
+				if i == 0 {
+					cell = "th"
+				}
+				b.WriteString("<tr>")
+				for _, text := range splitTableRow(row) {
+					b.WriteString("<" + cell + ">" + inlineHTML(text) + "</" + cell + ">")
+				}
+				b.WriteString("</tr>\n")
+			}
+			b.WriteString("</table>\n")
+		case "rule":
+			b.WriteString("<hr>\n")
+		}
+	}
+
+	return b.String()
+}
+
+func inlineHTML(text string) string {
+	var b strings.Builder
+	for _, span := range parseInline(text) {
+		escaped := html.EscapeString(span.text)
+		switch span.kind {
+		case "bold":
+			b.WriteString("<strong>" + escaped + "</strong>")
+		case "italic":
+			b.WriteString("<em>" + escaped + "</em>")
+		case "code":
+			b.WriteString("<code>" + escaped + "</code>")
+		case "link":
+			if safeURL(span.url) {
+				b.WriteString(`<a href="` + html.EscapeString(span.url) + `">` + escaped + "</a>")
+			} else {
+				b.WriteString(escaped)
+			}
+		default:
+			b.WriteString(strings.ReplaceAll(escaped, "\n", "<br>\n"))
+		}
+	}
+	return b.String()
+}
+
+// safeURL allows only web and mail links, so exported HTML can't carry
+// javascript: URLs
+func safeURL(url string) bool {
+	lower := strings.ToLower(url)
+	return strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "mailto:")
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseMarkdown(t *testing.T) {
+	src := "# Plan\n\nIntro line one\nline two\n\n1. First\n2. Second\n   continued\n\n> Quoted\n\n```go\nfmt.Println(\"hi\")\n```\n\n---\n- bullet"
+
+	blocks := parseMarkdown(src)
+
+	kinds := make([]string, len(blocks))
+	for i, b := range blocks {
+		kinds[i] = b.kind
+	}
+	want := "heading paragraph list quote code rule list"
+	if got := strings.Join(kinds, " "); got != want {
+		t.Fatalf("Expected blocks %q, got %q", want, got)
+	}
+
+	if blocks[1].lines[1] != "line two" {
+		t.Errorf("Unexpected paragraph lines: %q", blocks[1].lines)
+	}
+	if !blocks[2].ordered || blocks[2].lines[1] != "Second continued" {
+		t.Errorf("Unexpected list: %+v", blocks[2])
+	}
+	if blocks[4].lang != "go" || blocks[4].lines[0] != `fmt.Println("hi")` {
+		t.Errorf("Unexpected code block: %+v", blocks[4])
+	}
+}
+
+func TestParseInline(t *testing.T) {
+	spans := parseInline("Use **bold**, *italic*, `code` and [docs](https://example.com).")
+
+	var got []string
+	for _, s := range spans {
+		got = append(got, s.kind+":"+s.text)
+	}
+	want := "text:Use |bold:bold|text:, |italic:italic|text:, |code:code|text: and |link:docs|text:."
+	if strings.Join(got, "|") != want {
+		t.Errorf("Unexpected spans:\n got %s\nwant %s", strings.Join(got, "|"), want)
+	}
+}
+
+func TestMarkdownToHTMLEscapes(t *testing.T) {
+	out := markdownToHTML("<script>alert(1)</script> [x](javascript:alert(1)) [ok](https://example.com)\n\n```\n<b>\n```")
+
+	AssertNotContains(t, out, "<script>")
+	AssertNotContains(t, out, `href="javascript`)
+	AssertContains(t, out, "&lt;script&gt;")
+	AssertContains(t, out, `<a href="https://example.com">ok</a>`)
+	AssertContains(t, out, "<pre><code>&lt;b&gt;</code></pre>")
+}
+
+func TestParseMarkdownTable(t *testing.T) {
+	blocks := parseMarkdown("Scenes:\n| # | Beat |\n|---|:----|\n| 1 | Hook |\n| 2 | Demo |\n\n3. Third\n4. Fourth")
+
+	if len(blocks) != 3 || blocks[1].kind != "table" {
+		t.Fatalf("Expected paragraph, table and list, got %+v", blocks)
+	}
+	if len(blocks[1].lines) != 3 {
+		t.Errorf("Expected header and 2 rows, got %q", blocks[1].lines)
+	}
+	if cells := splitTableRow(blocks[1].lines[2]); len(cells) != 2 || cells[1] != "Demo" {
+		t.Errorf("Unexpected cells: %q", cells)
+	}
+	if blocks[2].start != 3 {
+		t.Errorf("Expected list to start at 3, got %d", blocks[2].start)
+	}
+}
+
+func TestRenderMarkdownWraps(t *testing.T) {
+	src := "## Plan\n\nOpen with a **bold**, clear claim, then show the product in use for a few seconds.\n\n- First point that is long enough to wrap onto another line\n- Second"
+
+	out := renderMarkdown(src, 30)
+	plain := ansiEscape.ReplaceAllString(out, "")
+
+	for _, line := range strings.Split(strings.TrimRight(plain, "\n"), "\n") {
+		if len([]rune(line)) > 30 {
+			t.Errorf("Line exceeds width: %q", line)
+		}
+	}
+
+	AssertContains(t, out, styleBold+"bold"+styleReset+",")
+	AssertContains(t, plain, "Plan\n")
+	AssertContains(t, plain, "  • First point")
+	AssertContains(t, plain, "\n    enough to wrap")
+	AssertNotContains(t, plain, "**")
+	AssertNotContains(t, plain, "##")
+}
+
+func TestRenderMarkdownBlocks(t *testing.T) {
+	src := "```\nlong code line that must not be wrapped at all\n```\n\n> Quoted\n\n| Scene | Length |\n|---|---|\n| Hook | 3s |\n\nSee [docs](https://example.com)"
+
+	plain := ansiEscape.ReplaceAllString(renderMarkdown(src, 40), "")
+
+	AssertContains(t, plain, "    long code line that must not be wrapped at all\n")
+	AssertContains(t, plain, "│ Quoted")
+	AssertContains(t, plain, "  Scene │ Length\n")
+	AssertContains(t, plain, "  ──────┼───────\n")
+	AssertContains(t, plain, "  Hook  │ 3s\n")
+	AssertContains(t, plain, "docs (https://example.com)")
+}
+
+func TestMarkdownStream(t *testing.T) {
+	var out strings.Builder
+	m := &markdownStream{out: &out, width: 80}
+
+	for _, delta := range []string{"Some **bo", "ld** text\n", "\n```\ncode\n", "\nmore\n```\n\n", "- item"} {
+		m.Write(delta)
+	}
+	if strings.Contains(out.String(), "item") {
+		t.Error("Incomplete block should be held back")
+	}
+	m.Flush()
+
+	plain := ansiEscape.ReplaceAllString(out.String(), "")
+	want := "Some bold text\n\n    code\n    \n    more\n\n  • item\n"
+	if plain != want {
+		t.Errorf("Unexpected output:\n%q\nwant\n%q", plain, want)
+	}
+}
@@ -3,12 +3,13 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -25,7 +26,7 @@ var productionsListCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
 		}
 
 		workspaceID := GetWorkspaceID()
@@ -35,40 +36,21 @@ var productionsListCmd = &cobra.Command{
 
 		status, _ := cmd.Flags().GetString("status")
 		limit, _ := cmd.Flags().GetInt("limit")
+		watch, _ := cmd.Flags().GetBool("watch")
 
-		url := fmt.Sprintf("%s/workspaces/%s/productions?limit=%d", GetAPIURL(), workspaceID, limit)
-		if status != "" {
-			url += "&status=" + status
+		if watch {
+			return runWatch(cmd, apiKey, workspaceID)
 		}
-
-		req, _ := http.NewRequest("GET", url, nil)
-		req.Header.Set("Authorization", apiKey)
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return fmt.Errorf("request failed: %w", err)
+		if jsonEvents, _ := cmd.Flags().GetBool("json"); jsonEvents {
+			return &usageError{fmt.Errorf("--json requires --watch")}
 		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
-		}
-
-		var result struct {
-			Productions []struct {
-				ID        string `json:"id"`
-				Name      string `json:"name"`
-				Status    string `json:"status"`
-				Topic     string `json:"topic"`
-				CreatedAt string `json:"createdAt"`
-			} `json:"productions"`
-			NextCursor string `json:"nextCursor"`
-			HasMore    bool   `json:"hasMore"`
+		if cmd.Flags().Changed("interval") {
+			return &usageError{fmt.Errorf("--interval requires --watch")}
 		}
 
-		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-			return fmt.Errorf("failed to parse response: %w", err)
+		result, err := fetchProductions(apiKey, workspaceID, status, limit)
+		if err != nil {
+			return err
 		}
 
 		if len(result.Productions) == 0 {
@@ -99,13 +81,13 @@ var productionsListCmd = &cobra.Command{
 var productionsGetCmd = &cobra.Command{
 	Use:   "get [production-id]",
 	Short: "Get production details",
-	Args:  cobra.ExactArgs(1),
+	Args:  usageArgs(cobra.ExactArgs(1)),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		productionID := args[0]
 
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
 		}
 
 		workspaceID := GetWorkspaceID()
@@ -114,15 +96,14 @@ var productionsGetCmd = &cobra.Command{
 		req, _ := http.NewRequest("GET", url, nil)
 		req.Header.Set("Authorization", apiKey)
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := doRequest(req)
 		if err != nil {
 			return fmt.Errorf("request failed: %w", err)
 		}
 		defer resp.Body.Close()
 
 		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+			return newAPIError(resp)
 		}
 
 		var result map[string]interface{}
@@ -142,7 +123,7 @@ var productionsCreateCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
 		}
 
 		workspaceID := GetWorkspaceID()
@@ -201,17 +182,17 @@ var productionsCreateCmd = &cobra.Command{
 
 		req, _ := http.NewRequest("POST", url, bytes.NewReader(body))
 		req.Header.Set("Authorization", apiKey)
+		setIdempotencyKey(req)
 		req.Header.Set("Content-Type", "application/json")
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := doRequest(req)
 		if err != nil {
 			return fmt.Errorf("request failed: %w", err)
 		}
 		defer resp.Body.Close()
 
 		if resp.StatusCode != http.StatusCreated {
-			respBody, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+			return newAPIError(resp)
 		}
 
 		var result struct {
@@ -232,34 +213,55 @@ var productionsCreateCmd = &cobra.Command{
 }
 
 var productionsBuildCmd = &cobra.Command{
-	Use:   "build [production-id]",
+	Use:   "build [production-id...]",
 	Short: "Trigger a build for a production",
-	Args:  cobra.ExactArgs(1),
+	Long: `Trigger a build for a production.
+
+Pass several IDs, "-" to read IDs from stdin, or --all with --status to
+build many productions at once.
+
+Examples:
+  hy productions build prod_xxx
+  hy productions build prod_xxx --force     # Cancel in-flight build first
+  hy productions build cancel prod_xxx
+  hy productions build retry prod_xxx       # Retry last failed build
+  hy productions build --status failed --all
+  hy productions build prod_a prod_b prod_c --yes`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		productionID := args[0]
-
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
 		}
 
 		workspaceID := GetWorkspaceID()
 		validateOnly, _ := cmd.Flags().GetBool("validate-only")
+		force, _ := cmd.Flags().GetBool("force")
+		all, _ := cmd.Flags().GetBool("all")
+		status, _ := cmd.Flags().GetString("status")
+
+		if status != "" && !all {
+			return fmt.Errorf("--status requires --all")
+		}
+
+		if all || len(args) != 1 || args[0] == "-" {
+			return runBatchBuild(cmd, args, apiKey, workspaceID)
+		}
+
+		productionID := args[0]
 
 		// First, get the production to check if it has a spec
 		getURL := fmt.Sprintf("%s/workspaces/%s/productions/%s", GetAPIURL(), workspaceID, productionID)
 		getReq, _ := http.NewRequest("GET", getURL, nil)
 		getReq.Header.Set("Authorization", apiKey)
 
-		getResp, err := http.DefaultClient.Do(getReq)
+		getResp, err := doRequest(getReq)
 		if err != nil {
 			return fmt.Errorf("request failed: %w", err)
 		}
 		defer getResp.Body.Close()
 
 		if getResp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(getResp.Body)
-			return fmt.Errorf("API error (%d): %s", getResp.StatusCode, string(body))
+			return newAPIError(getResp)
 		}
 
 		var production struct {
@@ -284,85 +286,238 @@ var productionsBuildCmd = &cobra.Command{
 			return nil
 		}
 
+		// Cancel the in-flight build first when forced
+		if force && (production.Status == "queued" || production.Status == "building") {
+			if err := cancelBuild(apiKey, workspaceID, productionID); err != nil {
+				return err
+			}
+			fmt.Printf("✓ Cancelled in-flight build for %s\n", productionID)
+
+			result, err := triggerBuildAfterCancel(apiKey, workspaceID, productionID)
+			if err != nil {
+				return err
+			}
+			printBuildStarted(productionID, result)
+			return nil
+		}
+
 		// Trigger actual build
-		url := fmt.Sprintf("%s/workspaces/%s/productions/%s/build", GetAPIURL(), workspaceID, productionID)
+		return startBuild(apiKey, workspaceID, productionID, "build")
+	},
+}
 
-		req, _ := http.NewRequest("POST", url, nil)
-		req.Header.Set("Authorization", apiKey)
+var productionsBuildCancelCmd = &cobra.Command{
+	Use:   "cancel [production-id]",
+	Short: "Cancel the in-flight build for a production",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		productionID := args[0]
+
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		if err := cancelBuild(apiKey, GetWorkspaceID(), productionID); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Cancelled build for %s\n", productionID)
+		return nil
+	},
+}
+
+var productionsBuildRetryCmd = &cobra.Command{
+	Use:   "retry [production-id]",
+	Short: "Retry the last failed build with the same spec",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		productionID := args[0]
+
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		workspaceID := GetWorkspaceID()
+
+		// Check that the last build actually failed
+		statusURL := fmt.Sprintf("%s/workspaces/%s/productions/%s/build", GetAPIURL(), workspaceID, productionID)
+		statusReq, _ := http.NewRequest("GET", statusURL, nil)
+		statusReq.Header.Set("Authorization", apiKey)
 
-		resp, err := http.DefaultClient.Do(req)
+		statusResp, err := doRequest(statusReq)
 		if err != nil {
 			return fmt.Errorf("request failed: %w", err)
 		}
-		defer resp.Body.Close()
+		defer statusResp.Body.Close()
 
-		respBody, _ := io.ReadAll(resp.Body)
-
-		if resp.StatusCode == http.StatusAccepted {
-			var result struct {
-				ID      string `json:"id"`
-				Status  string `json:"status"`
-				BuildID string `json:"buildId"`
-				Message string `json:"message"`
-			}
-			json.Unmarshal(respBody, &result)
+		if statusResp.StatusCode != http.StatusOK {
+			return newAPIError(statusResp)
+		}
 
-			fmt.Printf("✓ Build started for %s\n", productionID)
-			if result.BuildID != "" {
-				fmt.Printf("  Build ID: %s\n", result.BuildID)
-			}
-			fmt.Printf("  %s\n", result.Message)
-			return nil
+		var last struct {
+			Status  string  `json:"status"`
+			BuildID *string `json:"buildId"`
 		}
+		json.NewDecoder(statusResp.Body).Decode(&last)
 
-		if resp.StatusCode == http.StatusConflict {
-			return fmt.Errorf("build already in progress for this production")
+		if last.BuildID == nil || *last.BuildID == "" {
+			return fmt.Errorf("production has no previous build to retry")
+		}
+		if last.Status != "failed" {
+			return fmt.Errorf("last build %s did not fail (status: %s)", *last.BuildID, last.Status)
 		}
 
-		return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+		fmt.Printf("Retrying build %s...\n", *last.BuildID)
+		return startBuild(apiKey, workspaceID, productionID, "build/retry")
 	},
 }
 
+// buildStarted is the response to a successful build trigger
+type buildStarted struct {
+	ID      string `json:"id"`
+	Status  string `json:"status"`
+	BuildID string `json:"buildId"`
+	Message string `json:"message"`
+}
+
+// startBuild POSTs to a build endpoint (build or build/retry) and prints the
+// resulting build ID.
+func startBuild(apiKey, workspaceID, productionID, path string) error {
+	result, err := triggerBuild(apiKey, workspaceID, productionID, path)
+	if err != nil {
+		return err
+	}
+
+	printBuildStarted(productionID, result)
+	return nil
+}
+
+func printBuildStarted(productionID string, result *buildStarted) {
+	fmt.Printf("✓ Build started for %s\n", productionID)
+	if result.BuildID != "" {
+		fmt.Printf("  Build ID: %s\n", result.BuildID)
+	}
+	fmt.Printf("  %s\n", result.Message)
+}
+
+// triggerBuild POSTs to a build endpoint without printing anything, so it can
+// be shared by single and batch builds.
+func triggerBuild(apiKey, workspaceID, productionID, path string) (*buildStarted, error) {
+	url := fmt.Sprintf("%s/workspaces/%s/productions/%s/%s", GetAPIURL(), workspaceID, productionID, path)
+
+	req, _ := http.NewRequest("POST", url, nil)
+	req.Header.Set("Authorization", apiKey)
+	setIdempotencyKey(req)
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode == http.StatusAccepted {
+		var result buildStarted
+		json.Unmarshal(respBody, &result)
+		return &result, nil
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		apiErr := parseAPIError(resp, respBody)
+		apiErr.Message = "build already in progress for this production (use --force to cancel it)"
+		return nil, apiErr
+	}
+
+	return nil, parseAPIError(resp, respBody)
+}
+
+// cancelBuild cancels the queued or running build for a production.
+func cancelBuild(apiKey, workspaceID, productionID string) error {
+	url := fmt.Sprintf("%s/workspaces/%s/productions/%s/build/cancel", GetAPIURL(), workspaceID, productionID)
+
+	req, _ := http.NewRequest("POST", url, nil)
+	req.Header.Set("Authorization", apiKey)
+	setIdempotencyKey(req)
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusConflict {
+		apiErr := newAPIError(resp)
+		apiErr.Message = "no build in progress for this production"
+		return apiErr
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
+
+// triggerBuildAfterCancel starts a build right after cancelling one. The
+// cancelled build can take a moment to stop, so 409 responses are retried
+// with backoff.
+func triggerBuildAfterCancel(apiKey, workspaceID, productionID string) (*buildStarted, error) {
+	retries := retriesSetting()
+	for attempt := 0; ; attempt++ {
+		result, err := triggerBuild(apiKey, workspaceID, productionID, "build")
+
+		var apiErr *APIError
+		if attempt >= retries || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusConflict {
+			return result, err
+		}
+
+		wait := backoff(attempt)
+		fmt.Fprintf(os.Stderr, "Retrying in %s (cancelled build is still stopping)...\n", wait.Round(100*time.Millisecond))
+		retrySleep(wait)
+	}
+}
+
 var productionsDeleteCmd = &cobra.Command{
-	Use:   "delete [production-id]",
+	Use:   "delete [production-id...]",
 	Short: "Delete a production (soft delete)",
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		productionID := args[0]
+	Long: `Delete one or more productions (soft delete).
+
+Pass several IDs, or "-" to read IDs from stdin (requires --force).
 
+Examples:
+  hy productions delete prod_xxx
+  hy productions delete prod_a prod_b prod_c
+  cat ids.txt | hy productions delete - --force`,
+	Args: usageArgs(cobra.MinimumNArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
 		}
 
 		workspaceID := GetWorkspaceID()
+		force, _ := cmd.Flags().GetBool("force")
+
+		if len(args) > 1 || args[0] == "-" {
+			return runBatchDelete(cmd, args, apiKey, workspaceID)
+		}
+
+		productionID := args[0]
 
 		// Confirm unless --force
-		force, _ := cmd.Flags().GetBool("force")
 		if !force {
 			fmt.Printf("Delete production %s? This can be undone within 30 days. [y/N] ", productionID)
-			var confirm string
-			fmt.Scanln(&confirm)
-			if strings.ToLower(confirm) != "y" && strings.ToLower(confirm) != "yes" {
+			if !confirmPrompt() {
 				fmt.Println("Cancelled")
 				return nil
 			}
 		}
 
-		url := fmt.Sprintf("%s/workspaces/%s/productions/%s", GetAPIURL(), workspaceID, productionID)
-
-		req, _ := http.NewRequest("DELETE", url, nil)
-		req.Header.Set("Authorization", apiKey)
-
-		resp, err := http.DefaultClient.Do(req)
-		if err != nil {
-			return fmt.Errorf("request failed: %w", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+		if err := deleteProduction(apiKey, workspaceID, productionID); err != nil {
+			return err
 		}
 
 		fmt.Printf("✓ Deleted production: %s\n", productionID)
@@ -372,73 +527,257 @@ var productionsDeleteCmd = &cobra.Command{
 	},
 }
 
+// deleteProduction soft-deletes a single production
+func deleteProduction(apiKey, workspaceID, productionID string) error {
+	url := fmt.Sprintf("%s/workspaces/%s/productions/%s", GetAPIURL(), workspaceID, productionID)
+
+	req, _ := http.NewRequest("DELETE", url, nil)
+	req.Header.Set("Authorization", apiKey)
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
+
 var productionsStatusCmd = &cobra.Command{
 	Use:   "status [production-id]",
 	Short: "Get build status for a production",
-	Args:  cobra.ExactArgs(1),
+	Args:  usageArgs(cobra.ExactArgs(1)),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		productionID := args[0]
 
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
+		}
+
+		buildID, _ := cmd.Flags().GetString("build")
+
+		result, err := fetchBuild(apiKey, GetWorkspaceID(), productionID, buildID)
+		if err != nil {
+			return err
+		}
+
+		printBuildStatus(result)
+		return nil
+	},
+}
+
+func printBuildStatus(result *buildRecord) {
+	fmt.Printf("Production: %s\n", result.ID)
+	fmt.Printf("Status:     %s\n", result.Status)
+
+	if result.BuildID != nil && *result.BuildID != "" {
+		fmt.Printf("Build ID:   %s\n", *result.BuildID)
+	}
+	if result.BuildLogURL != nil && *result.BuildLogURL != "" {
+		fmt.Printf("Logs:       %s\n", *result.BuildLogURL)
+	}
+	if result.BuildFinishedAt != nil && *result.BuildFinishedAt != "" {
+		fmt.Printf("Finished:   %s\n", *result.BuildFinishedAt)
+	}
+	if result.OutputURL != nil && *result.OutputURL != "" {
+		fmt.Printf("Output:     %s\n", *result.OutputURL)
+	}
+}
+
+var productionsReviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "List productions awaiting review",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
 		}
 
 		workspaceID := GetWorkspaceID()
-		url := fmt.Sprintf("%s/workspaces/%s/productions/%s/build", GetAPIURL(), workspaceID, productionID)
+		if workspaceID == "" {
+			return fmt.Errorf("no workspace configured. Run 'hy auth login' first")
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		url := fmt.Sprintf("%s/workspaces/%s/productions?limit=%d&status=review", GetAPIURL(), workspaceID, limit)
 
 		req, _ := http.NewRequest("GET", url, nil)
 		req.Header.Set("Authorization", apiKey)
 
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := doRequest(req)
 		if err != nil {
 			return fmt.Errorf("request failed: %w", err)
 		}
 		defer resp.Body.Close()
 
 		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+			return newAPIError(resp)
 		}
 
 		var result struct {
-			ID              string  `json:"id"`
-			Status          string  `json:"status"`
-			BuildID         *string `json:"buildId"`
-			BuildLogURL     *string `json:"buildLogUrl"`
-			BuildFinishedAt *string `json:"buildFinishedAt"`
-			OutputURL       *string `json:"outputUrl"`
+			Productions []struct {
+				ID        string  `json:"id"`
+				Name      string  `json:"name"`
+				Topic     string  `json:"topic"`
+				OutputURL *string `json:"outputUrl"`
+				UpdatedAt string  `json:"updatedAt"`
+			} `json:"productions"`
+			HasMore bool `json:"hasMore"`
 		}
-		json.NewDecoder(resp.Body).Decode(&result)
-
-		fmt.Printf("Production: %s\n", result.ID)
-		fmt.Printf("Status:     %s\n", result.Status)
 
-		if result.BuildID != nil && *result.BuildID != "" {
-			fmt.Printf("Build ID:   %s\n", *result.BuildID)
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
 		}
-		if result.BuildLogURL != nil && *result.BuildLogURL != "" {
-			fmt.Printf("Logs:       %s\n", *result.BuildLogURL)
+
+		if len(result.Productions) == 0 {
+			fmt.Println("No productions awaiting review")
+			return nil
 		}
-		if result.BuildFinishedAt != nil && *result.BuildFinishedAt != "" {
-			fmt.Printf("Finished:   %s\n", *result.BuildFinishedAt)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tUPDATED\tOUTPUT")
+		for _, p := range result.Productions {
+			output := "-"
+			if p.OutputURL != nil && *p.OutputURL != "" {
+				output = *p.OutputURL
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.ID, p.Name, p.UpdatedAt, output)
 		}
-		if result.OutputURL != nil && *result.OutputURL != "" {
-			fmt.Printf("Output:     %s\n", *result.OutputURL)
+		w.Flush()
+
+		if result.HasMore {
+			fmt.Printf("\n(more results available, use --limit)\n")
 		}
 
 		return nil
 	},
 }
 
+var productionsApproveCmd = &cobra.Command{
+	Use:   "approve [production-id]",
+	Short: "Approve a production that is in review",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return transitionProduction(args[0], "approve", nil)
+	},
+}
+
+var productionsRejectCmd = &cobra.Command{
+	Use:   "reject [production-id]",
+	Short: "Reject a production that is in review",
+	Long: `Reject a production that is in review and send it back to draft.
+
+Examples:
+  hy productions reject prod_xxx --reason "Hook is too slow"`,
+	Args: usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reason, _ := cmd.Flags().GetString("reason")
+		if reason == "" {
+			return fmt.Errorf("--reason is required")
+		}
+		return transitionProduction(args[0], "reject", map[string]interface{}{"reason": reason})
+	},
+}
+
+var productionsPublishCmd = &cobra.Command{
+	Use:   "publish [production-id]",
+	Short: "Publish an approved production",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return transitionProduction(args[0], "publish", nil)
+	},
+}
+
+// transitionProduction moves a production through the review workflow
+// (approve, reject, publish) and prints the resulting status.
+func transitionProduction(productionID, action string, payload map[string]interface{}) error {
+	apiKey := GetAPIKey()
+	if apiKey == "" {
+		return errNotAuthenticated
+	}
+
+	workspaceID := GetWorkspaceID()
+	url := fmt.Sprintf("%s/workspaces/%s/productions/%s/%s", GetAPIURL(), workspaceID, productionID, action)
+
+	var reqBody io.Reader
+	if payload != nil {
+		body, _ := json.Marshal(payload)
+		reqBody = bytes.NewReader(body)
+	}
+
+	req, _ := http.NewRequest("POST", url, reqBody)
+	req.Header.Set("Authorization", apiKey)
+	setIdempotencyKey(req)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode == http.StatusConflict {
+		var conflict struct {
+			Status string `json:"status"`
+		}
+		json.Unmarshal(respBody, &conflict)
+
+		apiErr := parseAPIError(resp, respBody)
+		apiErr.Message = fmt.Sprintf("cannot %s production in its current status", action)
+		if conflict.Status != "" {
+			apiErr.Message = fmt.Sprintf("cannot %s production in status %q", action, conflict.Status)
+		}
+		return apiErr
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return parseAPIError(resp, respBody)
+	}
+
+	var result struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+	json.Unmarshal(respBody, &result)
+
+	verbs := map[string]string{
+		"approve": "Approved",
+		"reject":  "Rejected",
+		"publish": "Published",
+	}
+
+	fmt.Printf("✓ %s production: %s\n", verbs[action], productionID)
+	if result.Status != "" {
+		fmt.Printf("  Status: %s\n", result.Status)
+	}
+
+	return nil
+}
+
 func init() {
 	rootCmd.AddCommand(productionsCmd)
 	productionsCmd.AddCommand(productionsListCmd)
 	productionsCmd.AddCommand(productionsGetCmd)
 	productionsCmd.AddCommand(productionsCreateCmd)
 	productionsCmd.AddCommand(productionsBuildCmd)
+	productionsBuildCmd.AddCommand(productionsBuildCancelCmd)
+	productionsBuildCmd.AddCommand(productionsBuildRetryCmd)
 	productionsCmd.AddCommand(productionsDeleteCmd)
 	productionsCmd.AddCommand(productionsStatusCmd)
+	productionsCmd.AddCommand(productionsReviewCmd)
+	productionsCmd.AddCommand(productionsApproveCmd)
+	productionsCmd.AddCommand(productionsRejectCmd)
+	productionsCmd.AddCommand(productionsPublishCmd)
 
 	// List flags
 	productionsListCmd.Flags().String("status", "", "Filter by status (draft, queued, building, review, approved, published, failed)")
@@ -453,7 +792,20 @@ func init() {
 
 	// Build flags
 	productionsBuildCmd.Flags().Bool("validate-only", false, "Check spec validity without triggering build")
+	productionsBuildCmd.Flags().Bool("force", false, "Cancel any in-flight build before starting a new one")
+	productionsBuildCmd.Flags().Bool("all", false, "Build every production matching --status")
+	productionsBuildCmd.Flags().String("status", "", "Status filter for --all (e.g. failed)")
+	productionsBuildCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt for batch builds")
+	productionsBuildCmd.Flags().Int("concurrency", 4, "Maximum parallel requests for batch builds")
 
 	// Delete flags
 	productionsDeleteCmd.Flags().Bool("force", false, "Skip confirmation prompt")
+	productionsDeleteCmd.Flags().Int("concurrency", 4, "Maximum parallel requests for batch deletes")
+
+	// Status flags
+	productionsStatusCmd.Flags().String("build", "", "Show a specific historical build instead of the latest")
+
+	// Review flags
+	productionsReviewCmd.Flags().Int("limit", 20, "Maximum number of results")
+	productionsRejectCmd.Flags().String("reason", "", "Reason for rejection (required)")
 }
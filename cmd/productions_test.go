@@ -323,3 +323,240 @@ func TestProductionsBuildNoSpec(t *testing.T) {
 		t.Errorf("Error should mention missing spec: %v", err)
 	}
 }
+
+func TestProductionsReview(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var requestedURL string
+
+	tc.Server.Handle("GET", "/workspaces/ws_test123/productions", func(w http.ResponseWriter, r *http.Request) {
+		requestedURL = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ProductionsListResponse{
+			Productions: []ProductionResponse{
+				{
+					ID:        "prod_abc123",
+					Name:      "Ready For Review",
+					Status:    "review",
+					UpdatedAt: "2026-02-06T12:00:00Z",
+				},
+			},
+		})
+	})
+
+	output, err := ExecuteCommand("productions", "review")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if !strings.Contains(requestedURL, "status=review") {
+		t.Errorf("Expected review status filter in URL, got: %s", requestedURL)
+	}
+
+	AssertContains(t, output, "prod_abc123")
+	AssertContains(t, output, "Ready For Review")
+}
+
+func TestProductionsApprove(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/productions/prod_abc123/approve", http.StatusOK, map[string]interface{}{
+		"id":     "prod_abc123",
+		"status": "approved",
+	})
+
+	output, err := ExecuteCommand("productions", "approve", "prod_abc123")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "Approved production")
+	AssertContains(t, output, "approved")
+}
+
+func TestProductionsReject(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var receivedBody map[string]interface{}
+
+	tc.Server.Handle("POST", "/workspaces/ws_test123/productions/prod_abc123/reject", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &receivedBody)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":     "prod_abc123",
+			"status": "draft",
+		})
+	})
+
+	output, err := ExecuteCommand("productions", "reject", "prod_abc123", "--reason", "Hook is too slow")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if receivedBody["reason"] != "Hook is too slow" {
+		t.Errorf("Expected reason in request body, got '%v'", receivedBody["reason"])
+	}
+
+	AssertContains(t, output, "Rejected production")
+}
+
+func TestProductionsPublishConflict(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/productions/prod_abc123/publish", http.StatusConflict, map[string]interface{}{
+		"error":  "Production must be approved before publishing",
+		"status": "review",
+	})
+
+	_, err := ExecuteCommand("productions", "publish", "prod_abc123")
+	if err == nil {
+		t.Fatal("Expected error for conflict")
+	}
+	if !strings.Contains(err.Error(), "review") {
+		t.Errorf("Error should mention current status: %v", err)
+	}
+}
+
+func TestProductionsBuildCancel(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/productions/prod_abc123/build/cancel", http.StatusOK, map[string]interface{}{
+		"id":     "prod_abc123",
+		"status": "draft",
+	})
+
+	output, err := ExecuteCommand("productions", "build", "cancel", "prod_abc123")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "Cancelled build")
+}
+
+func TestProductionsBuildRetry(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123/build", http.StatusOK, map[string]interface{}{
+		"id":      "prod_abc123",
+		"status":  "failed",
+		"buildId": "build_old123",
+	})
+
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/productions/prod_abc123/build/retry", http.StatusAccepted, map[string]interface{}{
+		"id":      "prod_abc123",
+		"status":  "building",
+		"buildId": "build_new456",
+		"message": "Build started.",
+	})
+
+	output, err := ExecuteCommand("productions", "build", "retry", "prod_abc123")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "build_old123")
+	AssertContains(t, output, "build_new456")
+}
+
+func TestProductionsBuildRetryNotFailed(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123/build", http.StatusOK, map[string]interface{}{
+		"id":      "prod_abc123",
+		"status":  "review",
+		"buildId": "build_old123",
+	})
+
+	_, err := ExecuteCommand("productions", "build", "retry", "prod_abc123")
+	if err == nil {
+		t.Fatal("Expected error when last build did not fail")
+	}
+	if !strings.Contains(err.Error(), "did not fail") {
+		t.Errorf("Error should explain why retry was refused: %v", err)
+	}
+}
+
+func TestProductionsBuildForce(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	cancelled := false
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123", http.StatusOK, map[string]interface{}{
+		"id":     "prod_abc123",
+		"name":   "Test Production",
+		"status": "building",
+		"spec":   map[string]interface{}{"version": "2.0"},
+	})
+
+	tc.Server.Handle("POST", "/workspaces/ws_test123/productions/prod_abc123/build/cancel", func(w http.ResponseWriter, r *http.Request) {
+		cancelled = true
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "prod_abc123", "status": "draft"})
+	})
+
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/productions/prod_abc123/build", http.StatusAccepted, map[string]interface{}{
+		"id":      "prod_abc123",
+		"status":  "building",
+		"buildId": "build_xyz789",
+		"message": "Build started.",
+	})
+
+	output, err := ExecuteCommand("productions", "build", "prod_abc123", "--force")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if !cancelled {
+		t.Error("Expected in-flight build to be cancelled")
+	}
+
+	AssertContains(t, output, "Cancelled in-flight build")
+	AssertContains(t, output, "build_xyz789")
+}
+
+func TestProductionsBuildForceWaitsForCancel(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123", http.StatusOK, map[string]interface{}{
+		"id":     "prod_abc123",
+		"status": "building",
+		"spec":   map[string]interface{}{"version": "2.0"},
+	})
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/productions/prod_abc123/build/cancel", http.StatusOK, map[string]interface{}{
+		"id": "prod_abc123",
+	})
+
+	// The cancelled build takes two attempts to stop
+	attempts := 0
+	tc.Server.Handle("POST", "/workspaces/ws_test123/productions/prod_abc123/build", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Content-Type", "application/json")
+		if attempts < 3 {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]string{"error": "Build already in progress"})
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]string{"id": "prod_abc123", "buildId": "build_xyz789", "message": "Build started."})
+	})
+
+	output, err := ExecuteCommand("productions", "build", "prod_abc123", "--force")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 build attempts, got %d", attempts)
+	}
+	AssertContains(t, output, "build_xyz789")
+}
@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultProfile = "default"
+	defaultAPIURL  = "https://studio.hypewell.ai/api"
+)
+
+// profileFlags maps profile-scoped config keys to the global flags that
+// override them
+var profileFlags = map[string]string{
+	"api_url":      "api-url",
+	"workspace_id": "workspace",
+	"api_key":      "",
+}
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named profiles",
+	Long: `Manage named profiles for switching between workspaces and environments.
+
+Each profile has its own API URL, workspace and stored API key. The
+"default" profile uses the top-level keys in the config file.
+
+Select a profile per command with --profile or HY_PROFILE, or make it the
+default with 'hy profile use'. Profile names are case-insensitive.
+
+Examples:
+  hy profile add staging --api-url https://staging.hypewell.ai/api
+  hy --profile staging auth login
+  hy profile use staging
+  HY_PROFILE=default hy productions list`,
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		active := activeProfile()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "\tNAME\tAPI URL\tWORKSPACE")
+		for _, name := range profileNames() {
+			marker := ""
+			if name == active {
+				marker = "*"
+			}
+			apiURL := viper.GetString(profileKey(name, "api_url"))
+			if apiURL == "" {
+				apiURL = defaultAPIURL
+			}
+			workspaceID := viper.GetString(profileKey(name, "workspace_id"))
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", marker, name, apiURL, valueOrDash(workspaceID))
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var profileAddCmd = &cobra.Command{
+	Use:   "add [name]",
+	Short: "Add a profile",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := profileName(args[0])
+
+		if profileExists(name) {
+			return fmt.Errorf("profile %q already exists", name)
+		}
+		if strings.ContainsAny(name, ". ") {
+			return fmt.Errorf("profile names cannot contain dots or spaces")
+		}
+
+		apiURL, _ := cmd.Flags().GetString("api-url")
+		workspaceID, _ := cmd.Flags().GetString("workspace")
+
+		if apiURL == "" {
+			apiURL = defaultAPIURL
+		}
+
+		viper.Set(profileKey(name, "api_url"), apiURL)
+		if workspaceID != "" {
+			viper.Set(profileKey(name, "workspace_id"), workspaceID)
+		}
+
+		if err := saveConfig(); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Added profile: %s\n", name)
+		fmt.Printf("  Run 'hy --profile %s auth login' to authenticate\n", name)
+		return nil
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use [name]",
+	Short: "Set the default profile",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := profileName(args[0])
+
+		if !profileExists(name) {
+			return fmt.Errorf("profile %q not found. Run 'hy profile list' to see profiles", name)
+		}
+
+		viper.Set("current_profile", name)
+		if err := saveConfig(); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Using profile: %s\n", name)
+		return nil
+	},
+}
+
+var profileRemoveCmd = &cobra.Command{
+	Use:   "remove [name]",
+	Short: "Remove a profile and its stored API key",
+	Args:  usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := profileName(args[0])
+
+		if name == defaultProfile {
+			return fmt.Errorf("the default profile cannot be removed")
+		}
+		if !profileExists(name) {
+			return fmt.Errorf("profile %q not found", name)
+		}
+
+		if err := deleteAPIKey(name); err != nil {
+			fmt.Printf("Warning: could not remove stored API key: %v\n", err)
+		}
+
+		profiles := viper.GetStringMap("profiles")
+		delete(profiles, name)
+		viper.Set("profiles", profiles)
+
+		if viper.GetString("current_profile") == name {
+			viper.Set("current_profile", defaultProfile)
+		}
+
+		if err := saveConfig(); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Removed profile: %s\n", name)
+		return nil
+	},
+}
+
+// activeProfile returns the profile selected by --profile, HY_PROFILE or
+// 'hy profile use', in that order
+func activeProfile() string {
+	if f := rootCmd.PersistentFlags().Lookup("profile"); f != nil && f.Changed {
+		return profileName(f.Value.String())
+	}
+	if name := os.Getenv("HY_PROFILE"); name != "" {
+		return profileName(name)
+	}
+	if name := viper.GetString("current_profile"); name != "" {
+		return profileName(name)
+	}
+	return defaultProfile
+}
+
+// profileName normalizes a profile name given by the user. Names are
+// lowercased, since the config file's keys are read back lowercased.
+func profileName(name string) string {
+	return strings.ToLower(name)
+}
+
+// profileKey returns the config key holding key for the named profile. The
+// default profile uses top-level keys so existing configs keep working.
+func profileKey(name, key string) string {
+	if name == defaultProfile {
+		return key
+	}
+	return "profiles." + name + "." + key
+}
+
+// profileSetting reads a profile-scoped setting for the active profile.
+// Explicit --api-url/--workspace flags and HY_* env vars still win.
+func profileSetting(key string) string {
+	name := activeProfile()
+	if name == defaultProfile || settingOverridden(key) {
+		return viper.GetString(key)
+	}
+
+	value := viper.GetString(profileKey(name, key))
+	if value == "" && key == "api_url" {
+		return defaultAPIURL
+	}
+	return value
+}
+
+// setProfileSetting stores a profile-scoped setting for the active profile
+func setProfileSetting(key, value string) {
+	viper.Set(profileKey(activeProfile(), key), value)
+}
+
+func settingOverridden(key string) bool {
+	if os.Getenv("HY_"+strings.ToUpper(key)) != "" {
+		return true
+	}
+	if name := profileFlags[key]; name != "" {
+		if f := rootCmd.PersistentFlags().Lookup(name); f != nil && f.Changed {
+			return true
+		}
+	}
+	return false
+}
+
+// keyringAccount returns the keyring user for a profile's API key
+func keyringAccount(name string) string {
+	if name == defaultProfile {
+		return keyringUser
+	}
+	return keyringUser + ":" + name
+}
+
+func profileNames() []string {
+	names := []string{defaultProfile}
+	for name := range viper.GetStringMap("profiles") {
+		if name != defaultProfile {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
+
+func profileExists(name string) bool {
+	if name == defaultProfile {
+		return true
+	}
+	_, ok := viper.GetStringMap("profiles")[name]
+	return ok
+}
+
+// saveConfig writes the config file, creating it if it doesn't exist. The
+// file is readable only by the owner since it may hold legacy API keys.
+func saveConfig() error {
+	if err := viper.WriteConfig(); err != nil {
+		if err := viper.SafeWriteConfig(); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+	}
+	if path := viper.ConfigFileUsed(); path != "" {
+		os.Chmod(path, 0600)
+	}
+	return nil
+}
+
+// saveConfigWithout writes the config file with keys removed. Viper can't
+// unset a key, so the file is written from a copy of the settings; in memory
+// the keys are set to "".
+func saveConfigWithout(keys ...string) error {
+	settings := viper.AllSettings()
+	for _, key := range keys {
+		path := strings.Split(strings.ToLower(key), ".")
+		m := settings
+		for _, p := range path[:len(path)-1] {
+			m, _ = m[p].(map[string]interface{})
+		}
+		if m != nil {
+			delete(m, path[len(path)-1])
+		}
+		viper.Set(key, "")
+	}
+
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		path = filepath.Join(configDir(), "config.yaml")
+	}
+
+	out := viper.New()
+	out.SetConfigFile(path)
+	if err := out.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	if err := out.WriteConfig(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	os.Chmod(path, 0600)
+	return nil
+}
+
+// checkProfile fails early when --profile or HY_PROFILE names a profile that
+// doesn't exist, rather than silently using default settings
+func checkProfile(cmd *cobra.Command, args []string) error {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == profileCmd {
+			return nil
+		}
+	}
+	if name := activeProfile(); !profileExists(name) {
+		return fmt.Errorf("profile %q not found. Run 'hy profile add %s' first", name, name)
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileAddCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileRemoveCmd)
+
+	// Add flags (the global --api-url/--workspace are bound to the active
+	// profile, so these are local)
+	profileAddCmd.Flags().String("api-url", "", "API base URL for the profile")
+	profileAddCmd.Flags().String("workspace", "", "Workspace ID for the profile")
+}
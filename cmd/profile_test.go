@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/zalando/go-keyring"
+)
+
+func TestProfileAddUseList(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	output, err := ExecuteCommand("profile", "add", "staging", "--api-url", "https://staging.example.com/api", "--workspace", "ws_staging")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	AssertContains(t, output, "Added profile: staging")
+
+	if _, err := ExecuteCommand("profile", "use", "staging"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if GetAPIURL() != "https://staging.example.com/api" {
+		t.Errorf("Expected staging API URL, got %s", GetAPIURL())
+	}
+	if GetWorkspaceID() != "ws_staging" {
+		t.Errorf("Expected staging workspace, got %s", GetWorkspaceID())
+	}
+
+	output, err = ExecuteCommand("profile", "list")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	AssertContains(t, output, "default")
+	AssertContains(t, output, "* ")
+	AssertContains(t, output, "ws_staging")
+}
+
+func TestProfileNamesAreCaseInsensitive(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	output, err := ExecuteCommand("profile", "add", "Staging", "--workspace", "ws_staging")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	AssertContains(t, output, "Added profile: staging")
+
+	if _, err := ExecuteCommand("profile", "add", "STAGING"); err == nil {
+		t.Error("Expected error adding a profile that differs only in case")
+	}
+
+	if _, err := ExecuteCommand("profile", "use", "Staging"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if GetWorkspaceID() != "ws_staging" {
+		t.Errorf("Expected staging workspace, got %s", GetWorkspaceID())
+	}
+
+	if _, err := ExecuteCommand("--profile", "STAGING", "profile", "list"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if activeProfile() != "staging" {
+		t.Errorf("Expected --profile STAGING to select staging, got %s", activeProfile())
+	}
+}
+
+func TestProfileFlagSelectsProfile(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	// The staging profile points at the test server; the default does not
+	viper.Set("profiles.staging.api_url", tc.Server.URL)
+	viper.Set("profiles.staging.workspace_id", "ws_staging")
+	viper.Set("profiles.staging.api_key", "sk_live_staging")
+	viper.Set("api_url", "http://127.0.0.1:1")
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_staging/productions", http.StatusOK, ProductionsListResponse{
+		Productions: []ProductionResponse{{ID: "prod_staging", Name: "Staging Production", Status: "draft"}},
+	})
+
+	output, err := ExecuteCommand("--profile", "staging", "productions", "list")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "prod_staging")
+}
+
+func TestProfileUnknown(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	_, err := ExecuteCommand("--profile", "nope", "productions", "list")
+	if err == nil {
+		t.Fatal("Expected error for unknown profile")
+	}
+	if !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Error should mention missing profile: %v", err)
+	}
+}
+
+func TestProfileKeyringIsolation(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	viper.Set("profiles.staging.api_url", tc.Server.URL)
+	keyring.Set(serviceName, keyringAccount("staging"), "sk_live_staging")
+	keyring.Set(serviceName, keyringAccount(defaultProfile), "sk_live_default")
+
+	t.Setenv("HY_PROFILE", "staging")
+	if key := GetAPIKey(); key != "sk_live_staging" {
+		t.Errorf("Expected staging key, got %s", key)
+	}
+
+	t.Setenv("HY_PROFILE", "")
+	if key := GetAPIKey(); key != "sk_live_default" {
+		t.Errorf("Expected default key, got %s", key)
+	}
+}
+
+func TestProfileRemove(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	viper.Set("profiles.staging.api_url", "https://staging.example.com/api")
+	viper.Set("current_profile", "staging")
+
+	if _, err := ExecuteCommand("profile", "remove", "staging"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if profileExists("staging") {
+		t.Error("Expected staging profile to be removed")
+	}
+	if activeProfile() != defaultProfile {
+		t.Errorf("Expected fallback to default profile, got %s", activeProfile())
+	}
+
+	if _, err := ExecuteCommand("profile", "remove", "default"); err == nil {
+		t.Error("Expected error removing default profile")
+	}
+}
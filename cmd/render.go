@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Terminal styles for rendered Markdown
+const (
+	styleBold    = "\033[1m"
+	styleDim     = "\033[2m"
+	styleItalic  = "\033[3m"
+	styleLink    = "\033[4;34m"
+	styleCode    = "\033[36m"
+	styleHeading = "\033[1;35m"
+	styleReset   = "\033[0m"
+)
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// outputWidth returns the width to wrap rendered output to: the terminal
+// width, then $COLUMNS, then 80
+func outputWidth() int {
+	if w := terminalWidth(os.Stdout); w > 0 {
+		return w
+	}
+	if w, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && w > 0 {
+		return w
+	}
+	return 80
+}
+
+// renderMarkdown formats Markdown for display in a terminal, wrapping text
+// to width columns. Each line ends with a newline.
+func renderMarkdown(src string, width int) string {
+	if width < 20 {
+		width = 20
+	}
+
+	var b strings.Builder
+	for i, block := range parseMarkdown(src) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		renderBlock(&b, block, width)
+	}
+	return b.String()
+}
+
+func renderBlock(b *strings.Builder, block mdBlock, width int) {
+	switch block.kind {
+	case "heading":
+		prefix := ""
+		if block.level > 2 {
+			prefix = strings.Repeat("#", block.level) + " "
+		}
+		b.WriteString(wrapWords(termWords(parseInline(block.lines[0]), styleHeading), width, prefix, ""))
+
+	case "paragraph":
+		b.WriteString(wrapWords(termWords(parseInline(strings.Join(block.lines, " ")), ""), width, "", ""))
+
+	case "list":
+		for i, item := range block.lines {
+			marker := "  • "
+			if block.ordered {
+				marker = fmt.Sprintf("  %d. ", block.start+i)
+			}
+			indent := strings.Repeat(" ", utf8.RuneCountInString(marker))
+			b.WriteString(wrapWords(termWords(parseInline(item), ""), width, marker, indent))
+		}
+
+	case "quote":
+		bar := styleDim + "│" + styleReset + " "
+		inner := renderMarkdown(strings.Join(block.lines, "\n"), width-2)
+		for _, line := range strings.SplitAfter(inner, "\n") {
+			if line != "" {
+				b.WriteString(bar + line)
+			}
+		}
+
+	case "code":
+		// Code is never wrapped; long lines are left to the terminal
+		for _, line := range block.lines {
+			b.WriteString("    " + styleCode + line + styleReset + "\n")
+		}
+
+	case "table":
+		renderTable(b, block.lines)
+
+	case "rule":
+		b.WriteString(styleDim + strings.Repeat("─", width) + styleReset + "\n")
+	}
+}
+
+// renderTable draws a table with aligned columns. Cells aren't wrapped.
+func renderTable(b *strings.Builder, rows []string) {
+	var cells [][]string
+	var widths []int
+	for _, row := range rows {
+		var styled []string
+		for i, text := range splitTableRow(row) {
+			cell := inlineTerm(text)
+			styled = append(styled, cell)
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], visibleWidth(cell))
+		}
+		cells = append(cells, styled)
+	}
+
+	for r, row := range cells {
+		var line []string
+		for i, w := range widths {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			if r == 0 {
+				cell = styleBold + cell + styleReset
+			}
+			line = append(line, cell+strings.Repeat(" ", w-visibleWidth(cell)))
+		}
+		b.WriteString("  " + strings.TrimRight(strings.Join(line, " │ "), " ") + "\n")
+
+		if r == 0 {
+			var sep []string
+			for _, w := range widths {
+				sep = append(sep, strings.Repeat("─", w))
+			}
+			b.WriteString("  " + strings.Join(sep, "─┼─") + "\n")
+		}
+	}
+}
+
+// termWord is a word of styled text; glue means no space precedes it
+type termWord struct {
+	text string
+	glue bool
+}
+
+// termWords splits inline spans into styled words. base is applied to every
+// word, e.g. for headings.
+func termWords(spans []mdSpan, base string) []termWord {
+	var words []termWord
+	space := true
+
+	add := func(text, style string) {
+		glue := !space && !startsWithSpace(text)
+		for _, f := range strings.Fields(text) {
+			if base+style != "" {
+				f = base + style + f + styleReset
+			}
+			words = append(words, termWord{text: f, glue: glue})
+			glue = false
+		}
+		space = text == "" || endsWithSpace(text)
+	}
+
+	for _, span := range spans {
+		switch span.kind {
+		case "bold":
+			add(span.text, styleBold)
+		case "italic":
+			add(span.text, styleItalic)
+		case "code":
+			add(span.text, styleCode)
+		case "link":
+			add(span.text, styleLink)
+			if span.url != span.text {
+				space = true
+				add("("+span.url+")", styleDim)
+			}
+		default:
+			add(span.text, "")
+		}
+	}
+
+	// Join glued words, e.g. a bold word followed by a comma, so the
+	// pair is never split across lines
+	var joined []termWord
+	for _, w := range words {
+		if w.glue && len(joined) > 0 {
+			joined[len(joined)-1].text += w.text
+			continue
+		}
+		joined = append(joined, w)
+	}
+	return joined
+}
+
+// inlineTerm renders inline Markdown on a single line
+func inlineTerm(text string) string {
+	var parts []string
+	for _, w := range termWords(parseInline(text), "") {
+		parts = append(parts, w.text)
+	}
+	return strings.Join(parts, " ")
+}
+
+// wrapWords lays out words in lines of at most width columns. The first line
+// starts with first and the rest with rest. Words longer than a line are
+// left whole.
+func wrapWords(words []termWord, width int, first, rest string) string {
+	var b strings.Builder
+	line, lineWidth := first, visibleWidth(first)
+	empty := true
+
+	for _, w := range words {
+		ww := visibleWidth(w.text)
+		if !empty && lineWidth+1+ww > width {
+			b.WriteString(line + "\n")
+			line, lineWidth = rest, visibleWidth(rest)
+			empty = true
+		}
+		if !empty {
+			line += " "
+			lineWidth++
+		}
+		line += w.text
+		lineWidth += ww
+		empty = false
+	}
+
+	b.WriteString(line + "\n")
+	return b.String()
+}
+
+// visibleWidth returns the number of columns s takes, ignoring escape codes
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(ansiEscape.ReplaceAllString(s, ""))
+}
+
+func startsWithSpace(s string) bool {
+	return s != "" && strings.TrimLeft(s, " \t\n") != s
+}
+
+func endsWithSpace(s string) bool {
+	return s != "" && strings.TrimRight(s, " \t\n") != s
+}
+
+// markdownStream renders a streamed reply a block at a time. Text is held
+// back until a blank line outside a code fence completes the block, since
+// emphasis and wrapping can't be decided before then.
+type markdownStream struct {
+	out     io.Writer
+	width   int
+	pending string
+	written bool
+}
+
+func (m *markdownStream) Write(delta string) {
+	m.pending += delta
+
+	lines := strings.Split(m.pending, "\n")
+	start, inFence := 0, false
+
+	// The last element is an incomplete line
+	for i, line := range lines[:len(lines)-1] {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if trimmed == "" && !inFence {
+			m.render(strings.Join(lines[start:i], "\n"))
+			start = i + 1
+		}
+	}
+
+	m.pending = strings.Join(lines[start:], "\n")
+}
+
+// Flush renders whatever is left at the end of the reply
+func (m *markdownStream) Flush() {
+	m.render(m.pending)
+	m.pending = ""
+}
+
+func (m *markdownStream) render(src string) {
+	if strings.TrimSpace(src) == "" {
+		return
+	}
+	if m.written {
+		fmt.Fprintln(m.out)
+	}
+	fmt.Fprint(m.out, renderMarkdown(src, m.width))
+	m.written = true
+}
@@ -0,0 +1,326 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxHistory is the number of REPL inputs kept per production
+const maxHistory = 500
+
+const replHelp = `Commands:
+  /help              Show this help
+  /history [n]       Show the last n thread messages (default 20)
+  /spec              Show the production spec
+  /build             Start a build of the production
+  /status            Show the production's build status
+  /switch <id>       Chat with another production ("workspace" for the workspace thread)
+  /attach <file|id>  Attach a file or asset to the next message
+  /apply N|all       Apply suggested changes to the spec
+  /reject N          Dismiss suggested changes
+  /clear             Clear the screen
+  /save [file]       Save this session's transcript as Markdown
+  /exit              End the conversation
+
+End a line with \ to continue on the next line, or wrap a block in """.`
+
+func (s *chatSession) runInteractive() error {
+	s.printBanner()
+	fmt.Println(`Type /help for commands, /exit to end the conversation.`)
+	fmt.Println()
+
+	reader := newLineReader()
+	reader.SetHistory(s.loadHistory())
+
+	for {
+		input, err := readInput(reader)
+		if errors.Is(err, errInterrupted) {
+			continue
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return fmt.Errorf("failed to read input: %w", err)
+			}
+			break
+		}
+
+		input = strings.TrimSpace(input)
+		if input == "" {
+			continue
+		}
+
+		reader.AddHistory(input)
+		s.appendHistory(input)
+
+		if input == "exit" || input == "quit" || input == "/exit" || input == "/quit" {
+			fmt.Println("Goodbye!")
+			break
+		}
+
+		if strings.HasPrefix(input, "/") {
+			err = s.runCommand(input, reader)
+		} else {
+			_, err = s.send(input)
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
+func (s *chatSession) printBanner() {
+	if s.productionID != "" {
+		fmt.Printf("Chatting with production: %s\n", s.productionID)
+	} else {
+		fmt.Println("Chatting with workspace assistant")
+	}
+	if s.threadID != "" {
+		fmt.Printf("Thread: %s\n", s.threadID)
+	}
+}
+
+// readInput reads one message, joining continuation lines ending in \ and
+// blocks wrapped in """ into a multi-line message. Input still pending when
+// the reader hits EOF is returned as the message.
+func readInput(reader lineReader) (string, error) {
+	line, err := reader.ReadLine("You: ")
+	if err != nil {
+		return "", err
+	}
+
+	if strings.TrimSpace(line) == `"""` {
+		var lines []string
+		for {
+			next, err := reader.ReadLine("... ")
+			if errors.Is(err, io.EOF) {
+				return strings.Join(lines, "\n"), nil
+			}
+			if err != nil {
+				return "", err
+			}
+			if strings.TrimSpace(next) == `"""` {
+				return strings.Join(lines, "\n"), nil
+			}
+			lines = append(lines, next)
+		}
+	}
+
+	lines := []string{}
+	for strings.HasSuffix(line, `\`) {
+		lines = append(lines, strings.TrimSuffix(line, `\`))
+		if line, err = reader.ReadLine("... "); errors.Is(err, io.EOF) {
+			return strings.Join(lines, "\n"), nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(append(lines, line), "\n"), nil
+}
+
+// runCommand handles a slash command
+func (s *chatSession) runCommand(input string, reader lineReader) error {
+	command, arg, _ := strings.Cut(input, " ")
+	arg = strings.TrimSpace(arg)
+
+	switch command {
+	case "/help":
+		fmt.Println(replHelp)
+	case "/history":
+		limit := 20
+		if arg != "" {
+			n, err := strconv.Atoi(arg)
+			if err != nil || n < 1 {
+				return fmt.Errorf("usage: /history [n]")
+			}
+			limit = n
+		}
+		messages, err := fetchThreadMessages(s.apiKey, s.url(), limit)
+		if err != nil {
+			return err
+		}
+		printThreadMessages(messages, s.render)
+	case "/spec":
+		if err := s.requireProduction(); err != nil {
+			return err
+		}
+		current, err := fetchProductionSpec(s.apiKey, s.workspaceID, s.productionID)
+		if err != nil {
+			return err
+		}
+		if current.Spec == nil {
+			fmt.Println("Production has no spec")
+			return nil
+		}
+		data, _ := json.MarshalIndent(current.Spec, "", "  ")
+		fmt.Printf("Spec version %d:\n%s\n", current.SpecVersion, data)
+	case "/build":
+		if err := s.requireProduction(); err != nil {
+			return err
+		}
+		return startBuild(s.apiKey, s.workspaceID, s.productionID, "build")
+	case "/status":
+		if err := s.requireProduction(); err != nil {
+			return err
+		}
+		result, err := fetchBuild(s.apiKey, s.workspaceID, s.productionID, "")
+		if err != nil {
+			return err
+		}
+		printBuildStatus(result)
+	case "/switch":
+		if arg == "" {
+			return fmt.Errorf("usage: /switch <production-id> or /switch workspace")
+		}
+		if arg == "workspace" {
+			arg = ""
+		}
+		s.productionID = arg
+		s.threadID = currentThread(s.workspaceID, s.productionID)
+		s.changes = nil
+		reader.SetHistory(s.loadHistory())
+		s.printBanner()
+	case "/attach":
+		if arg == "" {
+			return fmt.Errorf("usage: /attach <file> or /attach <asset-id>")
+		}
+		return s.attach(arg, os.Stdout)
+	case "/apply", "/reject":
+		nums, err := s.parseChangeNumbers(arg)
+		if err != nil {
+			return err
+		}
+		if command == "/apply" {
+			return s.apply(nums)
+		}
+		return s.reject(nums)
+	case "/clear":
+		if isTerminal(os.Stdout) {
+			fmt.Print("\033[H\033[2J")
+		}
+		s.printBanner()
+	case "/save":
+		path := arg
+		if path == "" {
+			path = fmt.Sprintf("hy-chat-%s-%s.md", s.historyName(), time.Now().Format("20060102-150405"))
+		}
+		if err := s.saveTranscript(path); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Saved transcript to %s\n", path)
+	default:
+		return fmt.Errorf("unknown command %s. Type /help for commands", command)
+	}
+
+	return nil
+}
+
+func (s *chatSession) requireProduction() error {
+	if s.productionID == "" {
+		return fmt.Errorf("no production selected. Use /switch <production-id>")
+	}
+	return nil
+}
+
+// saveTranscript writes the messages of this session as Markdown
+func (s *chatSession) saveTranscript(path string) error {
+	if len(s.transcript) == 0 {
+		return fmt.Errorf("nothing to save yet")
+	}
+
+	data := renderExportMarkdown(threadExport{
+		WorkspaceID:  s.workspaceID,
+		ProductionID: s.productionID,
+		ExportedAt:   time.Now().UTC().Format(time.RFC3339),
+		Messages:     s.transcript,
+	})
+
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		return fmt.Errorf("failed to save transcript: %w", err)
+	}
+	return nil
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// historyName identifies the thread whose input history is being used
+func (s *chatSession) historyName() string {
+	name := "workspace-" + s.workspaceID
+	if s.productionID != "" {
+		name = s.productionID
+	}
+	if s.threadID != "" {
+		name += "-" + s.threadID
+	}
+	return unsafeFileChars.ReplaceAllString(name, "_")
+}
+
+func (s *chatSession) historyPath() string {
+	return filepath.Join(configDir(), "history", s.historyName()+".jsonl")
+}
+
+// loadHistory reads saved REPL inputs for the current thread. Each line is
+// a JSON string so multi-line inputs survive.
+func (s *chatSession) loadHistory() []string {
+	f, err := os.Open(s.historyPath())
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var history []string
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadString('\n')
+		var entry string
+		if json.Unmarshal([]byte(line), &entry) == nil && entry != "" {
+			history = append(history, entry)
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	if len(history) > maxHistory {
+		history = history[len(history)-maxHistory:]
+	}
+	return history
+}
+
+// appendHistory saves an input, trimming the file when it grows well past
+// maxHistory. Failures are ignored; history is a convenience.
+func (s *chatSession) appendHistory(input string) {
+	path := s.historyPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return
+	}
+
+	entry, _ := json.Marshal(input)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	f.Write(append(entry, '\n'))
+	f.Close()
+
+	if info, err := os.Stat(path); err == nil && info.Size() > 1<<20 {
+		var b strings.Builder
+		for _, h := range s.loadHistory() {
+			data, _ := json.Marshal(h)
+			b.Write(append(data, '\n'))
+		}
+		os.WriteFile(path, []byte(b.String()), 0600)
+	}
+}
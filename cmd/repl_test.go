@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runREPL runs interactive chat with the given input and returns stdout
+func runREPL(t *testing.T, input string, args ...string) string {
+	t.Helper()
+
+	var err error
+	output := CaptureOutput(func() {
+		_, err = ExecuteCommandWithStdin(input, append([]string{"thread", "chat"}, args...)...)
+	})
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	return output
+}
+
+// handleEcho records every message and sends a fixed reply
+func handleEcho(tc *TestConfig, path string, received *[]string) {
+	tc.Server.Handle("POST", path, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		*received = append(*received, body["message"])
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ThreadResponse{
+			AssistantMessage: ThreadMessageResponse{Role: "assistant", Content: "Got it."},
+		})
+	})
+}
+
+func TestEditLine(t *testing.T) {
+	history := []string{"first", "second"}
+
+	tests := []struct {
+		name  string
+		keys  string
+		want  string
+		error error
+	}{
+		{"plain", "hello\r", "hello", nil},
+		{"insert after left arrow", "helo\x1b[Dl\r", "hello", nil},
+		{"backspace", "helpp\x7f\x7flo\r", "hello", nil},
+		{"home and end", "ello\x01h\x05!\r", "hello!", nil},
+		{"history up", "\x1b[A\r", "second", nil},
+		{"history up twice", "\x1b[A\x1b[A\r", "first", nil},
+		{"history down restores draft", "dra\x1b[A\x1b[Bft\r", "draft", nil},
+		{"kill line", "abc\x15xyz\r", "xyz", nil},
+		{"kill word", "foo bar\x17baz\r", "foo baz", nil},
+		{"kill to end", "hello world\x01\x1b[C\x1b[C\x1b[C\x1b[C\x1b[C\x0b\r", "hello", nil},
+		{"delete key", "hxello\x01\x1b[C\x1b[3~\r", "hello", nil},
+		{"unicode", "héllo\x1b[D\x1b[D\x1b[D\x7fe\r", "hello", nil},
+		{"ctrl+c", "abc\x03", "", errInterrupted},
+		{"ctrl+d on empty line", "\x04", "", io.EOF},
+		{"eof ends line", "hello", "hello", nil},
+		{"pasted newlines", "\x1b[200~one\r\ntwo\rthree\x1b[201~!\r", "one\ntwo\nthree!", nil},
+		{"keys after paste", "\x1b[200~abc\x1b[201~\x1b[D\x7f\r", "ac", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := editLine(bufio.NewReader(strings.NewReader(tt.keys)), io.Discard, "> ", history, 0)
+			if !errors.Is(err, tt.error) {
+				t.Fatalf("Expected error %v, got %v", tt.error, err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEditLineScrollsLongLines(t *testing.T) {
+	var out strings.Builder
+	got, err := editLine(bufio.NewReader(strings.NewReader("abcdefghijkl\x01\r")), &out, "> ", nil, 10)
+	if err != nil {
+		t.Fatalf("editLine failed: %v", err)
+	}
+	if got != "abcdefghijkl" {
+		t.Errorf("Expected full line, got %q", got)
+	}
+
+	// With 7 columns free after the prompt, the tail scrolls into view and
+	// Ctrl+A scrolls back to the start
+	AssertContains(t, out.String(), "\r> fghijkl\033[K")
+	if !strings.HasSuffix(out.String(), "\r> abcdefg\033[K\033[7D\r\n") {
+		t.Errorf("Unexpected redraw after Ctrl+A: %q", out.String())
+	}
+}
+
+func TestREPLUnterminatedBlockAtEOF(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var received []string
+	handleEcho(tc, "/workspaces/ws_test123/thread", &received)
+
+	runREPL(t, "\"\"\"\nblock one\nblock two\n")
+
+	if len(received) != 1 || received[0] != "block one\nblock two" {
+		t.Errorf("Expected the pending block to be sent, got %q", received)
+	}
+}
+
+func TestREPLLongAndMultiLineInput(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var received []string
+	handleEcho(tc, "/workspaces/ws_test123/thread", &received)
+
+	long := strings.Repeat("a", 100*1024)
+	input := long + "\n" +
+		"first line\\\nsecond line\n" +
+		"\"\"\"\nblock one\n\nblock two\n\"\"\"\n" +
+		"/exit\n"
+
+	runREPL(t, input)
+
+	if len(received) != 3 {
+		t.Fatalf("Expected 3 messages, got %d", len(received))
+	}
+	if received[0] != long {
+		t.Errorf("Long line was truncated to %d bytes", len(received[0]))
+	}
+	if received[1] != "first line\nsecond line" {
+		t.Errorf("Unexpected continuation message: %q", received[1])
+	}
+	if received[2] != "block one\n\nblock two" {
+		t.Errorf("Unexpected block message: %q", received[2])
+	}
+}
+
+func TestREPLProductionCommands(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	built := false
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123", http.StatusOK, map[string]interface{}{
+		"specVersion": 7,
+		"spec":        map[string]interface{}{"title": "Launch teaser"},
+	})
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123/build", http.StatusOK, map[string]interface{}{
+		"id":      "prod_abc123",
+		"status":  "building",
+		"buildId": "build_42",
+	})
+	tc.Server.Handle("POST", "/workspaces/ws_test123/productions/prod_abc123/build", func(w http.ResponseWriter, r *http.Request) {
+		built = true
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(map[string]string{"id": "prod_abc123", "buildId": "build_43", "message": "Build queued"})
+	})
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123/thread", http.StatusOK, ThreadHistoryResponse{
+		Messages: []ThreadMessageResponse{{Role: "assistant", Content: "Earlier reply"}},
+	})
+
+	output := runREPL(t, "/spec\n/status\n/build\n/history 5\n/bogus\n/exit\n", "-p", "prod_abc123")
+
+	AssertContains(t, output, "Spec version 7")
+	AssertContains(t, output, "Launch teaser")
+	AssertContains(t, output, "build_42")
+	AssertContains(t, output, "Build started for prod_abc123")
+	AssertContains(t, output, "Earlier reply")
+	AssertContains(t, output, "unknown command /bogus")
+
+	if !built {
+		t.Error("Expected /build to trigger a build")
+	}
+}
+
+func TestREPLSwitchAndSave(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var workspaceMsgs, productionMsgs []string
+	handleEcho(tc, "/workspaces/ws_test123/thread", &workspaceMsgs)
+	handleEcho(tc, "/workspaces/ws_test123/productions/prod_xyz/thread", &productionMsgs)
+
+	transcript := filepath.Join(tc.ConfigDir, "chat.md")
+	output := runREPL(t, "/spec\nhello\n/switch prod_xyz\nhi production\n/save "+transcript+"\n/exit\n")
+
+	AssertContains(t, output, "no production selected")
+	AssertContains(t, output, "Chatting with production: prod_xyz")
+	if len(workspaceMsgs) != 1 || len(productionMsgs) != 1 {
+		t.Errorf("Expected one message per thread, got %v and %v", workspaceMsgs, productionMsgs)
+	}
+
+	data, err := os.ReadFile(transcript)
+	if err != nil {
+		t.Fatalf("Transcript not saved: %v", err)
+	}
+	AssertContains(t, string(data), "hello")
+	AssertContains(t, string(data), "hi production")
+	AssertContains(t, string(data), "Got it.")
+}
+
+func TestREPLHistoryPersisted(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var received []string
+	handleEcho(tc, "/workspaces/ws_test123/productions/prod_abc123/thread", &received)
+
+	runREPL(t, "first question\nline one\\\nline two\n/exit\n", "-p", "prod_abc123")
+
+	session := &chatSession{workspaceID: "ws_test123", productionID: "prod_abc123"}
+	history := session.loadHistory()
+
+	want := []string{"first question", "line one\nline two", "/exit"}
+	if len(history) != len(want) {
+		t.Fatalf("Expected history %q, got %q", want, history)
+	}
+	for i := range want {
+		if history[i] != want[i] {
+			t.Errorf("History[%d] = %q, want %q", i, history[i], want[i])
+		}
+	}
+
+	other := &chatSession{workspaceID: "ws_test123", productionID: "prod_other"}
+	if len(other.loadHistory()) != 0 {
+		t.Error("History should be kept per production")
+	}
+}
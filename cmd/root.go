@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -33,10 +34,19 @@ func SetVersion(v string) {
 
 func init() {
 	cobra.OnInitialize(initConfig)
+	rootCmd.PersistentPreRunE = preRun
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &usageError{err}
+	})
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/hy/config.yaml)")
 	rootCmd.PersistentFlags().String("api-url", "", "API base URL")
 	rootCmd.PersistentFlags().String("workspace", "", "Workspace ID")
+	rootCmd.PersistentFlags().String("profile", "", "Profile to use (default is the current profile)")
+	rootCmd.PersistentFlags().String("credential-store", "", "Where to store API keys: keyring, file or env (default keyring)")
+	rootCmd.PersistentFlags().Duration("timeout", defaultTimeout, "How long to wait for the API to respond")
+	rootCmd.PersistentFlags().Duration("connect-timeout", defaultConnectTimeout, "How long to wait to connect to the API")
+	rootCmd.PersistentFlags().Int("retries", defaultRetries, "Retries for rate-limited and failed requests (0 to disable)")
 
 	viper.BindPFlag("api_url", rootCmd.PersistentFlags().Lookup("api-url"))
 	viper.BindPFlag("workspace_id", rootCmd.PersistentFlags().Lookup("workspace"))
@@ -46,13 +56,10 @@ func initConfig() {
 	if cfgFile != "" {
 		viper.SetConfigFile(cfgFile)
 	} else {
-		home, err := os.UserHomeDir()
-		cobra.CheckErr(err)
+		dir := configDir()
+		os.MkdirAll(dir, 0700)
 
-		configDir := home + "/.config/hy"
-		os.MkdirAll(configDir, 0755)
-
-		viper.AddConfigPath(configDir)
+		viper.AddConfigPath(dir)
 		viper.SetConfigType("yaml")
 		viper.SetConfigName("config")
 	}
@@ -62,19 +69,37 @@ func initConfig() {
 	viper.AutomaticEnv()
 
 	// Defaults
-	viper.SetDefault("api_url", "https://studio.hypewell.ai/api")
+	viper.SetDefault("api_url", defaultAPIURL)
 
 	if err := viper.ReadInConfig(); err == nil {
 		// Config file found and loaded
 	}
 }
 
-// GetAPIURL returns the configured API URL
+// preRun validates global settings before any command runs
+func preRun(cmd *cobra.Command, args []string) error {
+	if err := validateCredentialStore(); err != nil {
+		return err
+	}
+	return checkProfile(cmd, args)
+}
+
+// configDir returns the directory holding config.yaml and credentials
+func configDir() string {
+	if cfgFile != "" {
+		return filepath.Dir(cfgFile)
+	}
+	home, err := os.UserHomeDir()
+	cobra.CheckErr(err)
+	return filepath.Join(home, ".config", "hy")
+}
+
+// GetAPIURL returns the configured API URL for the active profile
 func GetAPIURL() string {
-	return viper.GetString("api_url")
+	return profileSetting("api_url")
 }
 
-// GetWorkspaceID returns the configured workspace ID
+// GetWorkspaceID returns the configured workspace ID for the active profile
 func GetWorkspaceID() string {
-	return viper.GetString("workspace_id")
+	return profileSetting("workspace_id")
 }
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// streamEvent is one event of a streamed chat response. Delta events carry
+// a chunk of the assistant's reply, done carries the complete response.
+type streamEvent struct {
+	Type    string          `json:"type"` // delta, done, error
+	Content string          `json:"content"`
+	Error   string          `json:"error"`
+	Data    json.RawMessage `json:"-"`
+}
+
+// readChatStream reads a streamed chat response in SSE or NDJSON format,
+// calling onDelta for each chunk of the reply as it arrives
+func readChatStream(body io.Reader, ndjson bool, onDelta func(string)) (*chatResponse, error) {
+	next := nextSSEEvent
+	if ndjson {
+		next = nextNDJSONEvent
+	}
+
+	r := bufio.NewReader(body)
+	var content strings.Builder
+
+	for {
+		event, err := next(r)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response stream: %w", err)
+		}
+
+		switch event.Type {
+		case "delta":
+			content.WriteString(event.Content)
+			onDelta(event.Content)
+		case "error":
+			return nil, fmt.Errorf("assistant error: %s", event.Error)
+		case "done":
+			var result chatResponse
+			if err := json.Unmarshal(event.Data, &result); err != nil {
+				return nil, fmt.Errorf("failed to parse response: %w", err)
+			}
+			if result.AssistantMessage.Content == "" {
+				result.AssistantMessage.Content = content.String()
+			}
+			return &result, nil
+		}
+	}
+
+	// The stream closed without a done event, so the reply may be cut short
+	// and wasn't necessarily saved
+	return nil, fmt.Errorf("reply stream ended unexpectedly")
+}
+
+// nextSSEEvent reads one server-sent event. The event name is the type and
+// the data is a JSON object.
+func nextSSEEvent(r *bufio.Reader) (*streamEvent, error) {
+	eventType := ""
+	var data []string
+
+	for {
+		line, err := r.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+
+		if line == "" {
+			if len(data) > 0 {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data = append(data, value)
+		}
+		// Comments (":keepalive") and unknown fields are ignored
+
+		if err != nil {
+			if len(data) > 0 {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	return decodeStreamEvent(eventType, []byte(strings.Join(data, "\n")))
+}
+
+// nextNDJSONEvent reads one line of newline-delimited JSON, skipping blank
+// lines. The type is given by the "type" field.
+func nextNDJSONEvent(r *bufio.Reader) (*streamEvent, error) {
+	for {
+		line, err := r.ReadString('\n')
+		if strings.TrimSpace(line) != "" {
+			return decodeStreamEvent("", []byte(line))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func decodeStreamEvent(eventType string, data []byte) (*streamEvent, error) {
+	var event streamEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("invalid event: %w", err)
+	}
+	if eventType != "" {
+		event.Type = eventType
+	}
+	if event.Type == "" {
+		event.Type = "message"
+	}
+	event.Data = data
+	return &event, nil
+}
@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// terminalWidth returns the width of the terminal f is attached to, or 0
+func terminalWidth(f *os.File) int {
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
@@ -9,8 +9,12 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"github.com/zalando/go-keyring"
 )
 
 // TestServer wraps httptest.Server with helper methods
@@ -76,6 +80,15 @@ func SetupTest(t *testing.T) *TestConfig {
 	// Reset viper for each test
 	viper.Reset()
 
+	// Use an in-memory keyring so tests never touch the real system keyring
+	keyring.MockInit()
+
+	// Retry immediately instead of backing off
+	retrySleep = func(time.Duration) {}
+
+	// Tests type into interactive mode through a pipe
+	stdinIsTerminal = func() bool { return true }
+
 	// Create test server
 	server := NewTestServer()
 
@@ -89,6 +102,10 @@ func SetupTest(t *testing.T) *TestConfig {
 		t.Fatalf("Failed to create temp dir: %v", err)
 	}
 
+	// Point config writes at the temp dir. initConfig runs on every Execute
+	// and would otherwise fall back to the real ~/.config/hy/config.yaml.
+	cfgFile = configDir + "/config.yaml"
+
 	return &TestConfig{
 		Server:    server,
 		ConfigDir: configDir,
@@ -99,6 +116,7 @@ func SetupTest(t *testing.T) *TestConfig {
 func (tc *TestConfig) Cleanup() {
 	tc.Server.Close()
 	os.RemoveAll(tc.ConfigDir)
+	cfgFile = ""
 	viper.Reset()
 }
 
@@ -120,8 +138,9 @@ func ExecuteCommand(args ...string) (string, error) {
 	os.Stdout = oldStdout
 	out, _ := io.ReadAll(r)
 
-	// Reset args for next test
+	// Reset args and flags for next test
 	rootCmd.SetArgs([]string{})
+	resetFlags(rootCmd)
 
 	return string(out), err
 }
@@ -133,8 +152,11 @@ func ExecuteCommandWithStdin(stdin string, args ...string) (string, error) {
 	// Save and replace stdin
 	oldStdin := os.Stdin
 	r, w, _ := os.Pipe()
-	w.WriteString(stdin)
-	w.Close()
+	// Write in the background so input larger than the pipe buffer doesn't block
+	go func() {
+		w.WriteString(stdin)
+		w.Close()
+	}()
 	os.Stdin = r
 
 	buf := new(bytes.Buffer)
@@ -147,12 +169,37 @@ func ExecuteCommandWithStdin(stdin string, args ...string) (string, error) {
 	// Restore stdin
 	os.Stdin = oldStdin
 
-	// Reset args for next test
+	// Reset args and flags for next test
 	rootCmd.SetArgs([]string{})
+	resetFlags(rootCmd)
 
 	return buf.String(), err
 }
 
+// resetFlags restores every flag to its default so values set by one test
+// don't leak into the next (commands are package-level globals)
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			sv.Replace(nil)
+			if f.DefValue != "[]" {
+				sv.Replace(strings.Split(strings.Trim(f.DefValue, "[]"), ","))
+			}
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, c := range cmd.Commands() {
+		resetFlags(c)
+	}
+}
+
 // CaptureOutput captures stdout during function execution
 func CaptureOutput(fn func()) string {
 	old := os.Stdout
@@ -217,11 +264,12 @@ type AssetsListResponse struct {
 }
 
 type KeyResponse struct {
-	ID        string   `json:"id"`
-	Name      string   `json:"name"`
-	KeyPrefix string   `json:"keyPrefix"`
-	Scopes    []string `json:"scopes"`
-	CreatedAt string   `json:"createdAt"`
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	KeyPrefix  string   `json:"keyPrefix"`
+	Scopes     []string `json:"scopes"`
+	CreatedAt  string   `json:"createdAt"`
+	LastUsedAt string   `json:"lastUsedAt,omitempty"`
 }
 
 type KeysListResponse struct {
@@ -1,14 +1,17 @@
 package cmd
 
 import (
-	"bufio"
 	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/spf13/cobra"
 )
@@ -19,22 +22,43 @@ var threadCmd = &cobra.Command{
 	Long: `Start a conversation with the AI assistant.
 
 Use workspace-level thread for general questions, or specify a production
-for context-aware assistance with your video script.`,
+for context-aware assistance with your video script.
+
+Each workspace and production has a default thread. Start parallel
+conversations with 'hy thread new' and switch between them with
+'hy thread use' or --thread.`,
 }
 
 var threadChatCmd = &cobra.Command{
 	Use:   "chat [message]",
 	Short: "Send a message to the thread",
-	Long: `Send a message and get a response from the AI assistant.
+	Long: `Send a message and get a response from the AI assistant. Replies are
+streamed as they are generated when the server supports it, and their
+Markdown is rendered when writing to a terminal (use --raw to disable).
 
 Examples:
   hy thread chat "How should I structure my video?"
   hy thread chat --production prod_xxx "Make the hook more engaging"
-  hy thread chat  # Interactive mode`,
+  hy thread chat --production prod_xxx --apply-all "Shorten the outro"
+  hy thread chat --attach ./script.md --asset asset_xxx "Turn this draft into a spec"
+  hy thread chat --production prod_xxx --thread thr_xxx "More voiceover options"
+  cat brief.md | hy thread chat -p prod_xxx --json  # Read the message from stdin
+  git diff | hy thread chat --system "Reply with a commit message only"
+  hy thread chat  # Interactive mode
+
+Without a message, piped stdin is sent as a single message; "-" reads
+stdin explicitly. Use --system to give the assistant instructions for this
+request, and --context-file to include the text of local files with the
+message. --json prints only the reply, as the assistant message and
+suggested changes.
+
+In a production thread, suggested changes are shown as diffs against the
+spec. Apply them with --apply-all, or with /apply N (or /apply all) and
+/reject N in interactive mode.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
 		}
 
 		workspaceID := GetWorkspaceID()
@@ -43,22 +67,103 @@ Examples:
 		}
 
 		productionID, _ := cmd.Flags().GetString("production")
+		noStream, _ := cmd.Flags().GetBool("no-stream")
+		applyAll, _ := cmd.Flags().GetBool("apply-all")
+		attachFiles, _ := cmd.Flags().GetStringArray("attach")
+		assets, _ := cmd.Flags().GetStringArray("asset")
+		jsonOutput, _ := cmd.Flags().GetBool("json")
+		system, _ := cmd.Flags().GetString("system")
+		contextFiles, _ := cmd.Flags().GetStringArray("context-file")
+
+		session := &chatSession{
+			apiKey:       apiKey,
+			workspaceID:  workspaceID,
+			productionID: productionID,
+			threadID:     selectedThread(cmd, workspaceID, productionID),
+			stream:       !noStream,
+			render:       shouldRender(cmd),
+			quiet:        jsonOutput,
+			system:       system,
+		}
 
-		// Build URL
-		var url string
-		if productionID != "" {
-			url = fmt.Sprintf("%s/workspaces/%s/productions/%s/thread", GetAPIURL(), workspaceID, productionID)
-		} else {
-			url = fmt.Sprintf("%s/workspaces/%s/thread", GetAPIURL(), workspaceID)
+		for _, path := range contextFiles {
+			file, err := readContextFile(path)
+			if err != nil {
+				return err
+			}
+			session.context = append(session.context, file)
 		}
 
-		// Interactive mode if no message provided
-		if len(args) == 0 {
-			return interactiveChat(url, apiKey, productionID)
+		// Interactive mode if no message provided and stdin isn't piped
+		readStdin := (len(args) == 0 && !stdinIsTerminal()) || (len(args) == 1 && args[0] == "-")
+		if len(args) == 0 && !readStdin {
+			if applyAll {
+				return fmt.Errorf("--apply-all requires a message")
+			}
+			if jsonOutput {
+				return fmt.Errorf("--json requires a message (pass - to read it from stdin)")
+			}
+			if len(attachFiles) > 0 || len(assets) > 0 {
+				return fmt.Errorf("--attach and --asset require a message (use /attach in interactive mode)")
+			}
+			session.interactive = true
+			return session.runInteractive()
+		}
+
+		if applyAll && productionID == "" {
+			return fmt.Errorf("--apply-all requires --production")
+		}
+		if applyAll && jsonOutput {
+			return fmt.Errorf("--apply-all can't be combined with --json")
 		}
 
 		message := strings.Join(args, " ")
-		return sendChatMessage(url, apiKey, message)
+		if readStdin {
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				return fmt.Errorf("failed to read message from stdin: %w", err)
+			}
+			message = strings.TrimSpace(string(data))
+			if message == "" {
+				return fmt.Errorf("no message on stdin")
+			}
+		}
+
+		// With --json, upload progress goes to stderr so stdout holds only
+		// the result
+		var progress io.Writer = os.Stdout
+		if jsonOutput {
+			progress = os.Stderr
+		}
+		if err := session.attachAll(attachFiles, assets, progress); err != nil {
+			return err
+		}
+
+		result, err := session.send(message)
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			out := chatOutput{
+				AssistantMessage: result.AssistantMessage,
+				SuggestedChanges: result.SuggestedChanges,
+			}
+			if out.SuggestedChanges == nil {
+				out.SuggestedChanges = []suggestedChange{}
+			}
+			data, _ := json.MarshalIndent(out, "", "  ")
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if applyAll {
+			if nums := session.pendingChanges(); len(nums) > 0 {
+				fmt.Println()
+				return session.apply(nums)
+			}
+		}
+		return nil
 	},
 }
 
@@ -68,7 +173,7 @@ var threadHistoryCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		apiKey := GetAPIKey()
 		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'hy auth login' first")
+			return errNotAuthenticated
 		}
 
 		workspaceID := GetWorkspaceID()
@@ -79,136 +184,532 @@ var threadHistoryCmd = &cobra.Command{
 		productionID, _ := cmd.Flags().GetString("production")
 		limit, _ := cmd.Flags().GetInt("limit")
 
-		var url string
-		if productionID != "" {
-			url = fmt.Sprintf("%s/workspaces/%s/productions/%s/thread?limit=%d", GetAPIURL(), workspaceID, productionID, limit)
-		} else {
-			url = fmt.Sprintf("%s/workspaces/%s/thread?limit=%d", GetAPIURL(), workspaceID, limit)
+		session := &chatSession{
+			apiKey:       apiKey,
+			workspaceID:  workspaceID,
+			productionID: productionID,
+			threadID:     selectedThread(cmd, workspaceID, productionID),
 		}
 
-		req, _ := http.NewRequest("GET", url, nil)
-		req.Header.Set("Authorization", apiKey)
-
-		resp, err := http.DefaultClient.Do(req)
+		messages, err := fetchThreadMessages(apiKey, session.url(), limit)
 		if err != nil {
-			return fmt.Errorf("request failed: %w", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
-		}
-
-		var result struct {
-			Messages []struct {
-				ID        string `json:"id"`
-				Role      string `json:"role"`
-				Content   string `json:"content"`
-				CreatedAt string `json:"createdAt"`
-			} `json:"messages"`
-		}
-		json.NewDecoder(resp.Body).Decode(&result)
-
-		if len(result.Messages) == 0 {
-			fmt.Println("No messages in thread")
-			return nil
-		}
-
-		for _, msg := range result.Messages {
-			prefix := "You:"
-			if msg.Role == "assistant" {
-				prefix = "AI:"
-			} else if msg.Role == "system" {
-				prefix = "System:"
-			}
-			fmt.Printf("\n%s\n%s\n", prefix, msg.Content)
+			return err
 		}
 
+		printThreadMessages(messages, shouldRender(cmd))
 		return nil
 	},
 }
 
-func sendChatMessage(url, apiKey, message string) error {
-	payload := map[string]string{"message": message}
-	body, _ := json.Marshal(payload)
+// threadPage is one page of a thread's messages
+type threadPage struct {
+	Messages   []chatMessage `json:"messages"`
+	NextCursor string        `json:"nextCursor"`
+	HasMore    bool          `json:"hasMore"`
+}
 
-	req, _ := http.NewRequest("POST", url, bytes.NewReader(body))
-	req.Header.Set("Authorization", apiKey)
-	req.Header.Set("Content-Type", "application/json")
+// fetchThreadMessages gets the most recent messages in a thread
+func fetchThreadMessages(apiKey, threadURL string, limit int) ([]chatMessage, error) {
+	page, err := fetchThreadPage(apiKey, threadURL, limit, "")
+	if err != nil {
+		return nil, err
+	}
+	return page.Messages, nil
+}
 
-	fmt.Println("Thinking...")
+func fetchThreadPage(apiKey, threadURL string, limit int, cursor string) (*threadPage, error) {
+	pageURL := fmt.Sprintf("%s?limit=%d", threadURL, limit)
+	if cursor != "" {
+		pageURL += "&cursor=" + url.QueryEscape(cursor)
+	}
+
+	req, _ := http.NewRequest("GET", pageURL, nil)
+	req.Header.Set("Authorization", apiKey)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := doRequest(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		respBody, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+		return nil, newAPIError(resp)
 	}
 
-	var result struct {
-		AssistantMessage struct {
-			Content string `json:"content"`
-		} `json:"assistantMessage"`
-		SuggestedChanges []struct {
-			Type        string `json:"type"`
-			Description string `json:"description"`
-		} `json:"suggestedChanges"`
+	var result threadPage
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
-	json.NewDecoder(resp.Body).Decode(&result)
 
-	fmt.Printf("\n%s\n", result.AssistantMessage.Content)
+	return &result, nil
+}
+
+// printThreadMessages prints messages, rendering the assistant's Markdown
+// when render is set
+func printThreadMessages(messages []chatMessage, render bool) {
+	if len(messages) == 0 {
+		fmt.Println("No messages in thread")
+		return
+	}
+
+	for _, msg := range messages {
+		fmt.Printf("\n%s\n%s", rolePrefix(msg.Role), formatReply(msg.Content, render && msg.Role == "assistant"))
+	}
+}
+
+// formatReply returns content rendered for the terminal, or as-is, ending in
+// a newline
+func formatReply(content string, render bool) string {
+	if render {
+		return renderMarkdown(content, outputWidth())
+	}
+	return content + "\n"
+}
+
+// shouldRender reports whether Markdown should be rendered: only on a
+// terminal, and not with --raw
+func shouldRender(cmd *cobra.Command) bool {
+	raw, _ := cmd.Flags().GetBool("raw")
+	return !raw && isTerminal(os.Stdout)
+}
+
+func rolePrefix(role string) string {
+	switch role {
+	case "assistant":
+		return "AI:"
+	case "system":
+		return "System:"
+	default:
+		return "You:"
+	}
+}
+
+// chatMessage is a message in a thread
+type chatMessage struct {
+	ID               string            `json:"id"`
+	Role             string            `json:"role"`
+	Content          string            `json:"content"`
+	CreatedAt        string            `json:"createdAt"`
+	SuggestedChanges []suggestedChange `json:"suggestedChanges,omitempty"`
+}
+
+// chatResponse is the result of sending a message, streamed or not
+type chatResponse struct {
+	UserMessage      chatMessage       `json:"userMessage"`
+	AssistantMessage chatMessage       `json:"assistantMessage"`
+	SuggestedChanges []suggestedChange `json:"suggestedChanges"`
+
+	// SpecVersion is the version of the production spec the suggested
+	// changes were made against, if the API reports it
+	SpecVersion int `json:"specVersion,omitempty"`
+}
+
+// chatOutput is what 'thread chat --json' prints
+type chatOutput struct {
+	AssistantMessage chatMessage       `json:"assistantMessage"`
+	SuggestedChanges []suggestedChange `json:"suggestedChanges"`
+}
+
+// stdinIsTerminal reports whether stdin is interactive; tests replace it
+var stdinIsTerminal = func() bool { return isTerminal(os.Stdin) }
+
+// chatAttachment references an asset attached to a message
+type chatAttachment struct {
+	AssetID string `json:"assetId"`
+	Name    string `json:"name,omitempty"`
+}
+
+// chatContext is the text of a local file sent along with a message
+type chatContext struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
 
-	if len(result.SuggestedChanges) > 0 {
-		fmt.Println("\n📝 Suggested changes:")
-		for _, change := range result.SuggestedChanges {
-			fmt.Printf("  • %s\n", change.Description)
+// maxContextFileSize limits --context-file; larger files should be
+// attached as assets instead
+const maxContextFileSize = 1 << 20
+
+// readContextFile reads a text file to send as context
+func readContextFile(path string) (chatContext, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return chatContext{}, fmt.Errorf("cannot read context file: %w", err)
+	}
+	if info.Size() > maxContextFileSize {
+		return chatContext{}, fmt.Errorf("context file %s is too large (%s, max 1 MB); use --attach instead", path, formatBytes(info.Size()))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return chatContext{}, fmt.Errorf("cannot read context file: %w", err)
+	}
+	if !utf8.Valid(data) {
+		return chatContext{}, fmt.Errorf("context file %s is not text; use --attach instead", path)
+	}
+
+	return chatContext{Name: filepath.Base(path), Content: string(data)}, nil
+}
+
+// chatSession holds the state of a conversation with a thread
+type chatSession struct {
+	apiKey       string
+	workspaceID  string
+	productionID string
+	threadID     string // named thread, or "" for the default thread
+	stream       bool
+	interactive  bool
+	render       bool // render Markdown replies for the terminal
+	quiet        bool // print nothing from send, e.g. for --json
+
+	// Instructions and file contents sent with every message
+	system  string
+	context []chatContext
+
+	// Messages sent and received in this session, for /save
+	transcript []chatMessage
+
+	// Attachments to send with the next message
+	attachments []chatAttachment
+
+	// Suggested changes from the last reply, numbered from 1. Entries are
+	// set to nil once applied or rejected.
+	changes []*suggestedChange
+
+	// specVersion is the spec version the changes were shown against. It's
+	// sent when applying them, so edits made since are detected.
+	specVersion int
+}
+
+func (s *chatSession) url() string {
+	if s.threadID != "" {
+		return threadsURL(s.workspaceID, s.productionID) + "/" + s.threadID
+	}
+	if s.productionID != "" {
+		return fmt.Sprintf("%s/workspaces/%s/productions/%s/thread", GetAPIURL(), s.workspaceID, s.productionID)
+	}
+	return fmt.Sprintf("%s/workspaces/%s/thread", GetAPIURL(), s.workspaceID)
+}
+
+// threadLabel describes the thread for messages, e.g. "thread thr_xxx of
+// prod_xxx"
+func (s *chatSession) threadLabel() string {
+	name := "the default thread"
+	if s.threadID != "" {
+		name = "thread " + s.threadID
+	}
+	if s.productionID != "" {
+		return name + " of " + s.productionID
+	}
+	return name + " of the workspace"
+}
+
+// send posts a message, printing the reply as it arrives and any suggested
+// changes as diffs against the production spec. In quiet mode nothing is
+// printed and the caller handles the result.
+func (s *chatSession) send(message string) (*chatResponse, error) {
+	if !s.quiet {
+		fmt.Println("Thinking...")
+	}
+
+	payload := map[string]interface{}{"message": message}
+	if len(s.attachments) > 0 {
+		payload["attachments"] = s.attachments
+	}
+	if s.system != "" {
+		payload["system"] = s.system
+	}
+	if len(s.context) > 0 {
+		payload["context"] = s.context
+	}
+
+	var md *markdownStream
+	if s.render {
+		md = &markdownStream{out: os.Stdout, width: outputWidth()}
+	}
+
+	streamed := false
+	result, err := postChatMessage(s.url(), s.apiKey, payload, s.stream && !s.quiet, func(delta string) {
+		if !streamed {
+			fmt.Println()
+			streamed = true
 		}
+		if md != nil {
+			md.Write(delta)
+		} else {
+			fmt.Print(delta)
+		}
+	})
+	if streamed {
+		if md != nil {
+			md.Flush()
+		} else {
+			fmt.Println()
+		}
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	if !streamed && !s.quiet {
+		fmt.Printf("\n%s", formatReply(result.AssistantMessage.Content, s.render))
+	}
+
+	s.attachments = nil
+
+	user := result.UserMessage
+	if user.Content == "" {
+		user = chatMessage{Role: "user", Content: message}
+	}
+	assistant := result.AssistantMessage
+	assistant.Role = "assistant"
+	s.transcript = append(s.transcript, user, assistant)
+
+	if !s.quiet {
+		s.showChanges(result.SuggestedChanges, result.SpecVersion)
+	}
+	return result, nil
 }
 
-func interactiveChat(url, apiKey, productionID string) error {
-	if productionID != "" {
-		fmt.Printf("Chatting with production: %s\n", productionID)
-	} else {
-		fmt.Println("Chatting with workspace assistant")
+// showChanges numbers the suggested changes of a reply and prints them as
+// diffs. specVersion is the version the reply says they're based on, if any;
+// otherwise the version of the spec the diffs are shown against is used.
+func (s *chatSession) showChanges(changes []suggestedChange, specVersion int) {
+	s.specVersion = specVersion
+	s.changes = make([]*suggestedChange, len(changes))
+	for i := range changes {
+		s.changes[i] = &changes[i]
+	}
+	if len(changes) == 0 {
+		return
 	}
-	fmt.Println("Type 'exit' or 'quit' to end the conversation.")
-	fmt.Println()
 
-	scanner := bufio.NewScanner(os.Stdin)
+	var spec map[string]interface{}
+	applicable := false
+	for _, c := range changes {
+		applicable = applicable || c.applicable()
+	}
+	if applicable && s.productionID != "" {
+		current, err := fetchProductionSpec(s.apiKey, s.workspaceID, s.productionID)
+		if err != nil {
+			fmt.Printf("Warning: could not load the spec to show diffs: %v\n", err)
+		} else {
+			spec = current.Spec
+			if s.specVersion == 0 {
+				s.specVersion = current.SpecVersion
+			}
+		}
+	}
+
+	fmt.Println("\n📝 Suggested changes:")
+	for i, change := range changes {
+		printChangeDiff(i+1, change, spec)
+	}
 
-	for {
-		fmt.Print("You: ")
-		if !scanner.Scan() {
-			break
+	if applicable && s.productionID != "" && s.interactive {
+		fmt.Println("\nUse /apply N (or /apply all) to apply, /reject N to dismiss")
+	}
+}
+
+// attach queues a local file or an existing asset for the next message.
+// Local files are uploaded as assets first. Progress is written to out.
+func (s *chatSession) attach(ref string, out io.Writer) error {
+	if _, err := os.Stat(ref); err == nil {
+		assetID, err := uploadAsset(s.apiKey, s.workspaceID, ref, "", "", out)
+		if err != nil {
+			return fmt.Errorf("failed to attach %s: %w", ref, err)
 		}
+		s.attachments = append(s.attachments, chatAttachment{AssetID: assetID, Name: filepath.Base(ref)})
+		fmt.Fprintf(out, "✓ Attached %s (%s)\n", filepath.Base(ref), assetID)
+		return nil
+	}
 
-		message := strings.TrimSpace(scanner.Text())
-		if message == "" {
-			continue
+	if !strings.HasPrefix(ref, "asset_") {
+		return fmt.Errorf("%s is not a file or an asset ID", ref)
+	}
+	s.attachments = append(s.attachments, chatAttachment{AssetID: ref})
+	fmt.Fprintf(out, "✓ Attached asset %s\n", ref)
+	return nil
+}
+
+// attachAll queues the files and assets given with --attach and --asset
+func (s *chatSession) attachAll(files, assets []string, out io.Writer) error {
+	// Check asset IDs before uploading anything
+	for _, assetID := range assets {
+		if !strings.HasPrefix(assetID, "asset_") {
+			return fmt.Errorf("%s is not an asset ID", assetID)
 		}
+	}
 
-		if message == "exit" || message == "quit" {
-			fmt.Println("Goodbye!")
-			break
+	for _, path := range files {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("cannot access attachment: %w", err)
+		}
+		if err := s.attach(path, out); err != nil {
+			return err
 		}
+	}
+	for _, assetID := range assets {
+		s.attachments = append(s.attachments, chatAttachment{AssetID: assetID})
+	}
+	return nil
+}
 
-		if err := sendChatMessage(url, apiKey, message); err != nil {
-			fmt.Printf("Error: %v\n", err)
+// apply applies the numbered suggested changes to the production spec
+func (s *chatSession) apply(nums []int) error {
+	if s.productionID == "" {
+		return fmt.Errorf("suggested changes can only be applied in a production thread (use --production)")
+	}
+
+	var changes []suggestedChange
+	for _, n := range nums {
+		change, err := s.change(n)
+		if err != nil {
+			return err
 		}
-		fmt.Println()
+		if !change.applicable() {
+			return fmt.Errorf("change %d can't be applied automatically", n)
+		}
+		changes = append(changes, *change)
+	}
+
+	// Apply against the version the changes were shown for, not the latest,
+	// so the API rejects them if the spec was edited in the meantime
+	if s.specVersion == 0 {
+		return fmt.Errorf("the spec version these changes are based on is unknown; ask the assistant again")
+	}
+
+	version, err := applySpecChanges(s.apiKey, s.workspaceID, s.productionID, s.specVersion, changes)
+	if err != nil {
+		return err
 	}
 
+	for _, n := range nums {
+		s.changes[n-1] = nil
+	}
+	s.specVersion = version
+
+	fmt.Printf("✓ Applied %s to %s (spec version %d)\n", pluralize(len(nums), "change", "changes"), s.productionID, version)
 	return nil
 }
 
+// reject dismisses the numbered suggested changes
+func (s *chatSession) reject(nums []int) error {
+	for _, n := range nums {
+		if _, err := s.change(n); err != nil {
+			return err
+		}
+	}
+	for _, n := range nums {
+		s.changes[n-1] = nil
+		fmt.Printf("✗ Rejected change %d\n", n)
+	}
+	return nil
+}
+
+func (s *chatSession) change(n int) (*suggestedChange, error) {
+	if n < 1 || n > len(s.changes) {
+		return nil, fmt.Errorf("no suggested change %d", n)
+	}
+	if s.changes[n-1] == nil {
+		return nil, fmt.Errorf("change %d was already applied or rejected", n)
+	}
+	return s.changes[n-1], nil
+}
+
+// pendingChanges returns the numbers of changes not yet applied or rejected
+// that can be applied automatically
+func (s *chatSession) pendingChanges() []int {
+	var nums []int
+	for i, c := range s.changes {
+		if c != nil && c.applicable() {
+			nums = append(nums, i+1)
+		}
+	}
+	return nums
+}
+
+// parseChangeNumbers parses "all" or a list of change numbers such as
+// "1,3" or "1 3"
+func (s *chatSession) parseChangeNumbers(arg string) ([]int, error) {
+	arg = strings.TrimSpace(arg)
+	if arg == "" {
+		return nil, fmt.Errorf("specify change numbers, e.g. 1 or 1,3 or all")
+	}
+	if arg == "all" {
+		nums := s.pendingChanges()
+		if len(nums) == 0 {
+			return nil, fmt.Errorf("no pending changes")
+		}
+		return nums, nil
+	}
+
+	var nums []int
+	seen := make(map[int]bool)
+	for _, field := range strings.FieldsFunc(arg, func(r rune) bool { return r == ',' || r == ' ' }) {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid change number %q", field)
+		}
+		// A number given twice is applied once
+		if !seen[n] {
+			seen[n] = true
+			nums = append(nums, n)
+		}
+	}
+	return nums, nil
+}
+
+func pluralize(n int, singular, plural string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, singular)
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+// postChatMessage sends a message to a thread. With stream set it asks for a
+// streamed reply (SSE or NDJSON) and calls onDelta as text arrives; servers
+// that don't stream answer with a single JSON body, which is handled the same.
+func postChatMessage(url, apiKey string, payload map[string]interface{}, stream bool, onDelta func(string)) (*chatResponse, error) {
+	body, _ := json.Marshal(payload)
+
+	req, _ := http.NewRequest("POST", url, bytes.NewReader(body))
+	req.Header.Set("Authorization", apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	if stream {
+		req.Header.Set("Accept", "text/event-stream, application/x-ndjson, application/json;q=0.5")
+	}
+
+	resp, err := doRequest(withSlowResponse(req))
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	switch mediaType(resp.Header.Get("Content-Type")) {
+	case "text/event-stream":
+		return readChatStream(resp.Body, false, onDelta)
+	case "application/x-ndjson", "application/jsonl":
+		return readChatStream(resp.Body, true, onDelta)
+	}
+
+	var result chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return &result, nil
+}
+
+// mediaType returns the media type of a Content-Type header without
+// parameters such as charset
+func mediaType(contentType string) string {
+	mt, _, _ := strings.Cut(contentType, ";")
+	return strings.ToLower(strings.TrimSpace(mt))
+}
+
 func init() {
 	rootCmd.AddCommand(threadCmd)
 	threadCmd.AddCommand(threadChatCmd)
@@ -216,8 +717,19 @@ func init() {
 
 	// Chat flags
 	threadChatCmd.Flags().StringP("production", "p", "", "Production ID for context-aware chat")
+	threadChatCmd.Flags().String("thread", "", "Thread ID (default is the thread in use)")
+	threadChatCmd.Flags().Bool("no-stream", false, "Wait for the complete reply instead of streaming it")
+	threadChatCmd.Flags().Bool("apply-all", false, "Apply all suggested changes to the production spec")
+	threadChatCmd.Flags().StringArray("attach", nil, "Upload a local file and attach it to the message (repeatable)")
+	threadChatCmd.Flags().StringArray("asset", nil, "Attach an existing asset by ID (repeatable)")
+	threadChatCmd.Flags().Bool("raw", false, "Print replies as raw Markdown")
+	threadChatCmd.Flags().Bool("json", false, "Print the assistant message and suggested changes as JSON")
+	threadChatCmd.Flags().String("system", "", "Instructions for the assistant for this request")
+	threadChatCmd.Flags().StringArray("context-file", nil, "Include a local text file with the message (repeatable)")
 
 	// History flags
 	threadHistoryCmd.Flags().StringP("production", "p", "", "Production ID")
+	threadHistoryCmd.Flags().String("thread", "", "Thread ID (default is the thread in use)")
 	threadHistoryCmd.Flags().Int("limit", 20, "Number of messages to fetch")
+	threadHistoryCmd.Flags().Bool("raw", false, "Print messages as raw Markdown")
 }
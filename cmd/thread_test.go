@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -203,3 +206,393 @@ func TestThreadHistoryWithLimit(t *testing.T) {
 		t.Errorf("Expected limit in URL, got: %s", requestedURL)
 	}
 }
+
+func TestThreadChatStreamSSE(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var accept string
+	tc.Server.Handle("POST", "/workspaces/ws_test123/thread", func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+
+		w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+		flusher := w.(http.Flusher)
+		for _, chunk := range []string{"Start ", "with a ", "hook."} {
+			fmt.Fprintf(w, "event: delta\ndata: {\"content\":%q}\n\n", chunk)
+			flusher.Flush()
+		}
+		fmt.Fprint(w, ": keepalive\n\n")
+		fmt.Fprint(w, "event: done\ndata: {\"assistantMessage\":{\"id\":\"msg_1\",\"content\":\"Start with a hook.\"},\n")
+		fmt.Fprint(w, "data: \"suggestedChanges\":[{\"type\":\"script\",\"description\":\"Tighten intro\"}]}\n\n")
+	})
+
+	output, err := ExecuteCommand("thread", "chat", "Help")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, accept, "text/event-stream")
+	AssertContains(t, output, "Start with a hook.")
+	AssertContains(t, output, "Tighten intro")
+	if strings.Count(output, "Start with a hook.") != 1 {
+		t.Errorf("Reply should be printed once, got:\n%s", output)
+	}
+}
+
+func TestThreadChatStreamNDJSON(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.Handle("POST", "/workspaces/ws_test123/thread", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		fmt.Fprintln(w, `{"type":"delta","content":"Hello "}`)
+		fmt.Fprintln(w, `{"type":"delta","content":"there"}`)
+		fmt.Fprintln(w, `{"type":"done","assistantMessage":{"content":"Hello there"}}`)
+	})
+
+	output, err := ExecuteCommand("thread", "chat", "Hi")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "Hello there")
+}
+
+func TestThreadChatStreamError(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.Handle("POST", "/workspaces/ws_test123/thread", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: delta\ndata: {\"content\":\"Partial\"}\n\n")
+		fmt.Fprint(w, "event: error\ndata: {\"error\":\"model overloaded\"}\n\n")
+	})
+
+	_, err := ExecuteCommand("thread", "chat", "Hi")
+	if err == nil {
+		t.Fatal("Expected error from stream error event")
+	}
+	AssertContains(t, err.Error(), "model overloaded")
+}
+
+func TestThreadChatStreamWithoutDone(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.Handle("POST", "/workspaces/ws_test123/thread", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: delta\ndata: {\"content\":\"Cut short\"}")
+	})
+
+	output, err := ExecuteCommand("thread", "chat", "Hi")
+	if err == nil {
+		t.Fatal("Expected error when the stream ends without a done event")
+	}
+	AssertContains(t, err.Error(), "reply stream ended unexpectedly")
+	AssertContains(t, output, "Cut short")
+}
+
+func TestThreadChatNoStream(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var accept string
+	tc.Server.Handle("POST", "/workspaces/ws_test123/thread", func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ThreadResponse{
+			AssistantMessage: ThreadMessageResponse{Content: "Blocking reply"},
+		})
+	})
+
+	output, err := ExecuteCommand("thread", "chat", "--no-stream", "Hi")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if strings.Contains(accept, "event-stream") {
+		t.Errorf("--no-stream should not request a stream, got Accept %q", accept)
+	}
+	AssertContains(t, output, "Blocking reply")
+}
+
+// handleAttachmentThread records chat payloads and accepts asset uploads
+func handleAttachmentThread(t *testing.T, tc *TestConfig, payloads *[]map[string]interface{}, uploaded *string) {
+	tc.Server.Handle("POST", "/workspaces/ws_test123/assets", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":        "asset_script1",
+			"uploadUrl": tc.Server.URL + "/upload/script",
+		})
+	})
+	tc.Server.Handle("PUT", "/upload/script", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		*uploaded = string(data)
+		w.WriteHeader(http.StatusOK)
+	})
+	tc.Server.Handle("POST", "/workspaces/ws_test123/thread", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		*payloads = append(*payloads, body)
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ThreadResponse{
+			AssistantMessage: ThreadMessageResponse{Content: "Read it."},
+		})
+	})
+}
+
+func TestThreadChatAttachments(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	script := filepath.Join(tc.ConfigDir, "script.md")
+	os.WriteFile(script, []byte("# Draft\nOpen on the product."), 0644)
+
+	var payloads []map[string]interface{}
+	var uploaded string
+	handleAttachmentThread(t, tc, &payloads, &uploaded)
+
+	output, err := ExecuteCommand("thread", "chat", "--attach", script, "--asset", "asset_ref9", "Turn this into a spec")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if uploaded != "# Draft\nOpen on the product." {
+		t.Errorf("Unexpected upload: %q", uploaded)
+	}
+	AssertContains(t, output, "Attached script.md (asset_script1)")
+
+	attachments, _ := payloads[0]["attachments"].([]interface{})
+	if len(attachments) != 2 {
+		t.Fatalf("Expected 2 attachments, got %v", payloads[0]["attachments"])
+	}
+	first := attachments[0].(map[string]interface{})
+	second := attachments[1].(map[string]interface{})
+	if first["assetId"] != "asset_script1" || first["name"] != "script.md" || second["assetId"] != "asset_ref9" {
+		t.Errorf("Unexpected attachments: %v", attachments)
+	}
+}
+
+func TestThreadChatJSONAttachKeepsStdoutClean(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	script := filepath.Join(tc.ConfigDir, "script.md")
+	os.WriteFile(script, []byte("# Draft"), 0644)
+
+	var payloads []map[string]interface{}
+	var uploaded string
+	handleAttachmentThread(t, tc, &payloads, &uploaded)
+
+	output, err := ExecuteCommand("thread", "chat", "--json", "--attach", script, "Turn this into a spec")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Errorf("Expected only JSON on stdout, got %q", output)
+	}
+}
+
+func TestThreadChatAttachMissingFile(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	_, err := ExecuteCommand("thread", "chat", "--attach", "/nonexistent/script.md", "Hi")
+	if err == nil {
+		t.Fatal("Expected error for missing attachment")
+	}
+}
+
+func TestThreadChatAssetRequiresID(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	script := filepath.Join(tc.ConfigDir, "script.md")
+	os.WriteFile(script, []byte("draft"), 0644)
+
+	var payloads []map[string]interface{}
+	var uploaded string
+	handleAttachmentThread(t, tc, &payloads, &uploaded)
+
+	_, err := ExecuteCommand("thread", "chat", "--attach", script, "--asset", "script.md", "Hi")
+	if err == nil {
+		t.Fatal("Expected error for an --asset value that isn't an asset ID")
+	}
+	AssertContains(t, err.Error(), "not an asset ID")
+	if uploaded != "" || len(payloads) != 0 {
+		t.Error("Nothing should be uploaded or sent when an asset ID is invalid")
+	}
+}
+
+func TestThreadChatInteractiveAttach(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	script := filepath.Join(tc.ConfigDir, "script.md")
+	os.WriteFile(script, []byte("draft"), 0644)
+
+	var payloads []map[string]interface{}
+	var uploaded string
+	handleAttachmentThread(t, tc, &payloads, &uploaded)
+
+	output := runREPL(t, "/attach "+script+"\nReview this\nAnd now?\n/exit\n")
+
+	AssertContains(t, output, "Attached script.md")
+	if len(payloads) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(payloads))
+	}
+	if _, ok := payloads[0]["attachments"]; !ok {
+		t.Error("Expected attachment on the first message")
+	}
+	if _, ok := payloads[1]["attachments"]; ok {
+		t.Error("Attachments should only be sent once")
+	}
+}
+
+func TestThreadChatRawWhenPiped(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.Handle("POST", "/workspaces/ws_test123/thread", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"assistantMessage": map[string]interface{}{"content": "## Plan\n\n**Open** strong"},
+		})
+	})
+
+	// Output is not a terminal, so Markdown is printed as-is
+	output, err := ExecuteCommand("thread", "chat", "--raw", "hello")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	AssertContains(t, output, "## Plan\n\n**Open** strong")
+	AssertNotContains(t, output, "\033[")
+}
+
+func TestThreadChatPipedJSON(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	contextPath := filepath.Join(tc.ConfigDir, "style.md")
+	os.WriteFile(contextPath, []byte("Keep it under 30 seconds"), 0644)
+
+	tc.Server.Handle("POST", "/workspaces/ws_test123/productions/prod_abc123/thread", func(w http.ResponseWriter, r *http.Request) {
+		if accept := r.Header.Get("Accept"); strings.Contains(accept, "event-stream") {
+			t.Errorf("--json should not request a stream, got Accept %q", accept)
+		}
+
+		var body struct {
+			Message string        `json:"message"`
+			System  string        `json:"system"`
+			Context []chatContext `json:"context"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+
+		if body.Message != "# Brief\n\nLaunch video for the new app" {
+			t.Errorf("Expected message from stdin, got %q", body.Message)
+		}
+		if body.System != "Reply in one line" {
+			t.Errorf("Expected system instructions, got %q", body.System)
+		}
+		if len(body.Context) != 1 || body.Context[0].Name != "style.md" || body.Context[0].Content != "Keep it under 30 seconds" {
+			t.Errorf("Unexpected context: %+v", body.Context)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"assistantMessage": map[string]interface{}{"id": "msg_2", "role": "assistant", "content": "Open on the app icon"},
+			"suggestedChanges": []map[string]interface{}{
+				{"description": "Shorter hook", "op": "replace", "path": "/scenes/0/script", "value": "Meet the app."},
+			},
+		})
+	})
+
+	var err error
+	output := CaptureOutput(func() {
+		_, err = ExecuteCommandWithStdin("# Brief\n\nLaunch video for the new app\n",
+			"thread", "chat", "-p", "prod_abc123", "--json", "--system", "Reply in one line", "--context-file", contextPath, "-")
+	})
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	var result chatOutput
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("Output is not JSON: %v\n%s", err, output)
+	}
+	var fields map[string]json.RawMessage
+	json.Unmarshal([]byte(output), &fields)
+	if len(fields) != 2 {
+		t.Errorf("Expected only assistantMessage and suggestedChanges, got %s", output)
+	}
+	if result.AssistantMessage.ID != "msg_2" || result.AssistantMessage.Content != "Open on the app icon" {
+		t.Errorf("Unexpected assistant message: %+v", result.AssistantMessage)
+	}
+	if len(result.SuggestedChanges) != 1 || result.SuggestedChanges[0].Path != "/scenes/0/script" {
+		t.Errorf("Expected suggested changes, got %+v", result.SuggestedChanges)
+	}
+}
+
+func TestThreadChatPipedWithoutArgs(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	stdinIsTerminal = func() bool { return false }
+
+	var received []string
+	handleEcho(tc, "/workspaces/ws_test123/thread", &received)
+
+	var err error
+	CaptureOutput(func() {
+		_, err = ExecuteCommandWithStdin("first line\nsecond line\n", "thread", "chat")
+	})
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if len(received) != 1 || received[0] != "first line\nsecond line" {
+		t.Errorf("Expected piped input as one message, got %q", received)
+	}
+}
+
+func TestThreadChatEmptyStdin(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var err error
+	CaptureOutput(func() {
+		_, err = ExecuteCommandWithStdin("  \n", "thread", "chat", "-")
+	})
+	if err == nil {
+		t.Fatal("Expected error for empty stdin")
+	}
+	AssertContains(t, err.Error(), "no message on stdin")
+}
+
+func TestThreadChatJSONRequiresMessage(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	_, err := ExecuteCommand("thread", "chat", "--json")
+	if err == nil {
+		t.Fatal("Expected error for --json without a message")
+	}
+}
+
+func TestThreadChatContextFileTooLarge(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	path := filepath.Join(tc.ConfigDir, "big.txt")
+	os.WriteFile(path, []byte(strings.Repeat("a", maxContextFileSize+1)), 0644)
+
+	_, err := ExecuteCommand("thread", "chat", "--context-file", path, "hello")
+	if err == nil {
+		t.Fatal("Expected error for oversized context file")
+	}
+	AssertContains(t, err.Error(), "too large")
+}
@@ -0,0 +1,337 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// defaultThread names the implicit thread every workspace and production has
+const defaultThread = "default"
+
+// threadInfo is a named thread
+type threadInfo struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	MessageCount int    `json:"messageCount"`
+	CreatedAt    string `json:"createdAt"`
+	UpdatedAt    string `json:"updatedAt"`
+}
+
+var threadNewCmd = &cobra.Command{
+	Use:   "new",
+	Short: "Start a new named thread",
+	Long: `Start a new named thread and use it for chat and history.
+
+Each workspace and production has a default thread; named threads let you
+hold parallel conversations, e.g. one for hook ideas and one for voiceover.
+
+Examples:
+  hy thread new -p prod_xxx --name "hook ideas"
+  hy thread chat -p prod_xxx "Give me five hooks"
+  hy thread use -p prod_xxx default  # Back to the default thread`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		workspaceID := GetWorkspaceID()
+		if workspaceID == "" {
+			return fmt.Errorf("no workspace configured. Run 'hy auth login' first")
+		}
+
+		productionID, _ := cmd.Flags().GetString("production")
+		name, _ := cmd.Flags().GetString("name")
+		if name == "" {
+			return fmt.Errorf("--name is required")
+		}
+
+		body, _ := json.Marshal(map[string]string{"name": name})
+
+		req, _ := http.NewRequest("POST", threadsURL(workspaceID, productionID), bytes.NewReader(body))
+		req.Header.Set("Authorization", apiKey)
+		setIdempotencyKey(req)
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := doRequest(req)
+		if err != nil {
+			return fmt.Errorf("request failed: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+			return newAPIError(resp)
+		}
+
+		var thread threadInfo
+		if err := json.NewDecoder(resp.Body).Decode(&thread); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		setCurrentThread(workspaceID, productionID, thread.ID)
+		if err := saveConfig(); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Created thread: %s (%s)\n", thread.Name, thread.ID)
+		useCmd := "hy thread use default"
+		if productionID != "" {
+			useCmd = fmt.Sprintf("hy thread use -p %s default", productionID)
+		}
+		fmt.Printf("  Chat and history now use this thread. Run '%s' to switch back\n", useCmd)
+		return nil
+	},
+}
+
+var threadListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List threads",
+	Long: `List the threads of the workspace or a production. The thread in use
+is marked with *.
+
+Examples:
+  hy thread list
+  hy thread list -p prod_xxx`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		workspaceID := GetWorkspaceID()
+		if workspaceID == "" {
+			return fmt.Errorf("no workspace configured. Run 'hy auth login' first")
+		}
+
+		productionID, _ := cmd.Flags().GetString("production")
+
+		threads, err := fetchThreads(apiKey, workspaceID, productionID)
+		if err != nil {
+			return err
+		}
+
+		current := currentThread(workspaceID, productionID)
+		marker := func(id string) string {
+			if id == current {
+				return "*"
+			}
+			return ""
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "\tID\tNAME\tMESSAGES\tUPDATED")
+		fmt.Fprintf(w, "%s\t%s\t%s\t-\t-\n", marker(""), defaultThread, "(default thread)")
+		for _, t := range threads {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", marker(t.ID), t.ID, t.Name, t.MessageCount, valueOrDash(formatTimestamp(t.UpdatedAt)))
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var threadUseCmd = &cobra.Command{
+	Use:   "use <thread-id>",
+	Short: "Set the thread used for chat and history",
+	Long: `Set the thread used by chat, history and export for the workspace or a
+production. Use "default" to go back to the default thread.
+
+Examples:
+  hy thread use -p prod_xxx thr_xxx
+  hy thread use -p prod_xxx default`,
+	Args: usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		workspaceID := GetWorkspaceID()
+		if workspaceID == "" {
+			return fmt.Errorf("no workspace configured. Run 'hy auth login' first")
+		}
+
+		productionID, _ := cmd.Flags().GetString("production")
+		threadID := args[0]
+
+		name := defaultThread
+		if threadID == defaultThread {
+			threadID = ""
+		} else {
+			thread, err := findThread(apiKey, workspaceID, productionID, threadID)
+			if err != nil {
+				return err
+			}
+			name = thread.Name
+		}
+
+		setCurrentThread(workspaceID, productionID, threadID)
+		if err := saveConfig(); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Using thread: %s\n", name)
+		return nil
+	},
+}
+
+var threadClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete a thread's messages to reset its context",
+	Long: `Delete every message in a thread so the assistant starts from a clean
+context. Named threads keep their name.
+
+Examples:
+  hy thread clear -p prod_xxx
+  hy thread clear -p prod_xxx --thread thr_xxx --force`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		workspaceID := GetWorkspaceID()
+		if workspaceID == "" {
+			return fmt.Errorf("no workspace configured. Run 'hy auth login' first")
+		}
+
+		productionID, _ := cmd.Flags().GetString("production")
+		force, _ := cmd.Flags().GetBool("force")
+
+		session := &chatSession{
+			apiKey:       apiKey,
+			workspaceID:  workspaceID,
+			productionID: productionID,
+			threadID:     selectedThread(cmd, workspaceID, productionID),
+		}
+
+		// Confirm unless --force
+		if !force {
+			fmt.Printf("Delete all messages in %s? This cannot be undone. [y/N] ", session.threadLabel())
+			if !confirmPrompt() {
+				fmt.Println("Cancelled")
+				return nil
+			}
+		}
+
+		req, _ := http.NewRequest("DELETE", session.url()+"/messages", nil)
+		req.Header.Set("Authorization", apiKey)
+
+		resp, err := doRequest(req)
+		if err != nil {
+			return fmt.Errorf("request failed: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+			return newAPIError(resp)
+		}
+
+		fmt.Printf("✓ Cleared %s\n", session.threadLabel())
+		return nil
+	},
+}
+
+// threadsURL is the collection of named threads of the workspace or a
+// production
+func threadsURL(workspaceID, productionID string) string {
+	if productionID != "" {
+		return fmt.Sprintf("%s/workspaces/%s/productions/%s/threads", GetAPIURL(), workspaceID, productionID)
+	}
+	return fmt.Sprintf("%s/workspaces/%s/threads", GetAPIURL(), workspaceID)
+}
+
+func fetchThreads(apiKey, workspaceID, productionID string) ([]threadInfo, error) {
+	req, _ := http.NewRequest("GET", threadsURL(workspaceID, productionID), nil)
+	req.Header.Set("Authorization", apiKey)
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result struct {
+		Threads []threadInfo `json:"threads"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return result.Threads, nil
+}
+
+// findThread looks a named thread up by ID
+func findThread(apiKey, workspaceID, productionID, threadID string) (*threadInfo, error) {
+	threads, err := fetchThreads(apiKey, workspaceID, productionID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range threads {
+		if threads[i].ID == threadID {
+			return &threads[i], nil
+		}
+	}
+	return nil, fmt.Errorf("thread %s not found. Run 'hy thread list' to see threads", threadID)
+}
+
+// currentThreadKey is the config key holding the thread in use for the
+// workspace or a production, per profile
+func currentThreadKey(workspaceID, productionID string) string {
+	scope := "workspace"
+	if productionID != "" {
+		scope = productionID
+	}
+	return profileKey(activeProfile(), "threads."+workspaceID+"."+scope)
+}
+
+// currentThread returns the thread set with 'hy thread use', or "" for the
+// default thread
+func currentThread(workspaceID, productionID string) string {
+	return viper.GetString(currentThreadKey(workspaceID, productionID))
+}
+
+func setCurrentThread(workspaceID, productionID, threadID string) {
+	viper.Set(currentThreadKey(workspaceID, productionID), threadID)
+}
+
+// selectedThread returns the thread given with --thread, else the one set
+// with 'hy thread use'. "" is the default thread.
+func selectedThread(cmd *cobra.Command, workspaceID, productionID string) string {
+	if f := cmd.Flags().Lookup("thread"); f != nil && f.Changed {
+		if f.Value.String() == defaultThread {
+			return ""
+		}
+		return f.Value.String()
+	}
+	return currentThread(workspaceID, productionID)
+}
+
+func init() {
+	threadCmd.AddCommand(threadNewCmd)
+	threadCmd.AddCommand(threadListCmd)
+	threadCmd.AddCommand(threadUseCmd)
+	threadCmd.AddCommand(threadClearCmd)
+
+	threadNewCmd.Flags().StringP("production", "p", "", "Production ID")
+	threadNewCmd.Flags().String("name", "", "Thread name (required)")
+
+	threadListCmd.Flags().StringP("production", "p", "", "Production ID")
+
+	threadUseCmd.Flags().StringP("production", "p", "", "Production ID")
+
+	threadClearCmd.Flags().StringP("production", "p", "", "Production ID")
+	threadClearCmd.Flags().String("thread", "", "Thread ID (default is the thread in use)")
+	threadClearCmd.Flags().Bool("force", false, "Skip confirmation prompt")
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func handleThreadList(tc *TestConfig) {
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123/threads", http.StatusOK, map[string]interface{}{
+		"threads": []map[string]interface{}{
+			{"id": "thr_hooks", "name": "hook ideas", "messageCount": 4, "updatedAt": "2026-02-06T12:00:00Z"},
+			{"id": "thr_vo", "name": "voiceover", "messageCount": 0},
+		},
+	})
+}
+
+func TestThreadNew(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.Handle("POST", "/workspaces/ws_test123/productions/prod_abc123/threads", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "hook ideas" {
+			t.Errorf("Expected name 'hook ideas', got %q", body["name"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "thr_hooks", "name": "hook ideas"})
+	})
+
+	output, err := ExecuteCommand("thread", "new", "-p", "prod_abc123", "--name", "hook ideas")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	AssertContains(t, output, "Created thread: hook ideas (thr_hooks)")
+	AssertContains(t, output, "hy thread use -p prod_abc123 default")
+
+	if got := currentThread("ws_test123", "prod_abc123"); got != "thr_hooks" {
+		t.Errorf("Expected new thread to be in use, got %q", got)
+	}
+	if got := currentThread("ws_test123", ""); got != "" {
+		t.Errorf("Workspace thread should be unchanged, got %q", got)
+	}
+}
+
+func TestThreadNewRequiresName(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	_, err := ExecuteCommand("thread", "new")
+	if err == nil {
+		t.Fatal("Expected error without --name")
+	}
+}
+
+func TestThreadList(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	handleThreadList(tc)
+	setCurrentThread("ws_test123", "prod_abc123", "thr_vo")
+
+	output, err := ExecuteCommand("thread", "list", "-p", "prod_abc123")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "default")
+	AssertContains(t, output, "thr_hooks")
+	AssertContains(t, output, "2026-02-06 12:00 UTC")
+	AssertContains(t, output, "*  thr_vo")
+}
+
+func TestThreadUse(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	handleThreadList(tc)
+
+	output, err := ExecuteCommand("thread", "use", "-p", "prod_abc123", "thr_hooks")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	AssertContains(t, output, "Using thread: hook ideas")
+	if got := viper.GetString("threads.ws_test123.prod_abc123"); got != "thr_hooks" {
+		t.Errorf("Expected thread saved in config, got %q", got)
+	}
+
+	resetFlags(rootCmd)
+	if _, err := ExecuteCommand("thread", "use", "-p", "prod_abc123", "default"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	if got := currentThread("ws_test123", "prod_abc123"); got != "" {
+		t.Errorf("Expected default thread, got %q", got)
+	}
+}
+
+func TestThreadUseUnknown(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	handleThreadList(tc)
+
+	_, err := ExecuteCommand("thread", "use", "-p", "prod_abc123", "thr_missing")
+	if err == nil {
+		t.Fatal("Expected error for unknown thread")
+	}
+	AssertContains(t, err.Error(), "not found")
+}
+
+func TestThreadChatUsesSelectedThread(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	reply := map[string]interface{}{"assistantMessage": map[string]interface{}{"content": "Here are some hooks"}}
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/productions/prod_abc123/threads/thr_hooks", http.StatusOK, reply)
+	tc.Server.HandleJSON("POST", "/workspaces/ws_test123/productions/prod_abc123/threads/thr_vo", http.StatusOK, reply)
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123/productions/prod_abc123/threads/thr_vo", http.StatusOK, map[string]interface{}{
+		"messages": []map[string]interface{}{{"role": "user", "content": "Voiceover draft"}},
+	})
+
+	setCurrentThread("ws_test123", "prod_abc123", "thr_hooks")
+
+	output, err := ExecuteCommand("thread", "chat", "-p", "prod_abc123", "--no-stream", "hooks please")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	AssertContains(t, output, "Here are some hooks")
+
+	// --thread overrides the thread in use
+	resetFlags(rootCmd)
+	if _, err := ExecuteCommand("thread", "chat", "-p", "prod_abc123", "--thread", "thr_vo", "--no-stream", "hi"); err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	resetFlags(rootCmd)
+	output, err = ExecuteCommand("thread", "history", "-p", "prod_abc123", "--thread", "thr_vo")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	AssertContains(t, output, "Voiceover draft")
+}
+
+func TestThreadClear(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	cleared := ""
+	tc.Server.Handle("DELETE", "/workspaces/ws_test123/productions/prod_abc123/threads/thr_hooks/messages", func(w http.ResponseWriter, r *http.Request) {
+		cleared = "thr_hooks"
+		w.WriteHeader(http.StatusNoContent)
+	})
+	tc.Server.Handle("DELETE", "/workspaces/ws_test123/thread/messages", func(w http.ResponseWriter, r *http.Request) {
+		cleared = "workspace"
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	output, err := ExecuteCommand("thread", "clear", "-p", "prod_abc123", "--thread", "thr_hooks", "--force")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	AssertContains(t, output, "Cleared thread thr_hooks of prod_abc123")
+	if cleared != "thr_hooks" {
+		t.Errorf("Expected named thread to be cleared, got %q", cleared)
+	}
+
+	// Declining the prompt leaves the thread alone
+	cleared = ""
+	resetFlags(rootCmd)
+	output = CaptureOutput(func() {
+		_, err = ExecuteCommandWithStdin("n\n", "thread", "clear")
+	})
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+	AssertContains(t, output, "Delete all messages in the default thread of the workspace?")
+	AssertContains(t, output, "Cancelled")
+	if cleared != "" {
+		t.Error("Thread should not be cleared when declined")
+	}
+}
@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// productionSummary is a production as returned by the list endpoint
+type productionSummary struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Topic     string `json:"topic"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type productionList struct {
+	Productions []productionSummary `json:"productions"`
+	NextCursor  string              `json:"nextCursor"`
+	HasMore     bool                `json:"hasMore"`
+}
+
+// watchEvent is a single change between two refreshes of the list
+type watchEvent struct {
+	Type string `json:"type"` // added, removed, status_changed
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	From string `json:"from,omitempty"`
+	To   string `json:"to,omitempty"`
+	At   string `json:"at"`
+}
+
+var productionsWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Watch productions and highlight status changes",
+	Long: `Refresh the production list on an interval and highlight status
+transitions (e.g. building → review). Press Ctrl+C to stop.
+
+With --json, only change events are written, one JSON object per line.
+
+Examples:
+  hy productions watch
+  hy productions watch --status building --interval 10s
+  hy productions watch --json | jq .`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		workspaceID := GetWorkspaceID()
+		if workspaceID == "" {
+			return fmt.Errorf("no workspace configured. Run 'hy auth login' first")
+		}
+
+		return runWatch(cmd, apiKey, workspaceID)
+	},
+}
+
+// fetchProductions gets one page of the production list
+func fetchProductions(apiKey, workspaceID, status string, limit int) (*productionList, error) {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(limit))
+	if status != "" {
+		query.Set("status", status)
+	}
+	endpoint := fmt.Sprintf("%s/workspaces/%s/productions?%s", GetAPIURL(), workspaceID, query.Encode())
+
+	req, _ := http.NewRequest("GET", endpoint, nil)
+	req.Header.Set("Authorization", apiKey)
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result productionList
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
+
+func runWatch(cmd *cobra.Command, apiKey, workspaceID string) error {
+	status, _ := cmd.Flags().GetString("status")
+	limit, _ := cmd.Flags().GetInt("limit")
+	interval, _ := cmd.Flags().GetDuration("interval")
+	jsonEvents, _ := cmd.Flags().GetBool("json")
+
+	if interval < time.Second {
+		return &usageError{fmt.Errorf("--interval must be at least 1s")}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var prev []productionSummary
+	first := true
+
+	for {
+		result, err := fetchProductions(apiKey, workspaceID, status, limit)
+		if err != nil {
+			// Keep watching through network and server failures; anything
+			// else, such as a revoked key, won't fix itself
+			if code := ExitCode(err); code != ExitNetwork && code != ExitServer {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		} else {
+			events := diffProductions(prev, result.Productions, first, !result.HasMore, time.Now())
+			if jsonEvents {
+				enc := json.NewEncoder(os.Stdout)
+				for _, e := range events {
+					enc.Encode(e)
+				}
+			} else {
+				renderWatch(result.Productions, events, interval)
+			}
+			prev = result.Productions
+			first = false
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// diffProductions returns the changes between two snapshots of the list.
+// The first refresh has nothing to compare with, so it yields no events.
+// When cur isn't the complete list, a production missing from it may just
+// be past the limit, so no removals are reported.
+func diffProductions(prev, cur []productionSummary, first, complete bool, now time.Time) []watchEvent {
+	if first {
+		return nil
+	}
+
+	at := now.UTC().Format(time.RFC3339)
+	before := make(map[string]productionSummary, len(prev))
+	for _, p := range prev {
+		before[p.ID] = p
+	}
+
+	var events []watchEvent
+	seen := make(map[string]bool, len(cur))
+	for _, p := range cur {
+		seen[p.ID] = true
+		old, ok := before[p.ID]
+		switch {
+		case !ok:
+			events = append(events, watchEvent{Type: "added", ID: p.ID, Name: p.Name, To: p.Status, At: at})
+		case ok && old.Status != p.Status:
+			events = append(events, watchEvent{Type: "status_changed", ID: p.ID, Name: p.Name, From: old.Status, To: p.Status, At: at})
+		}
+	}
+	for _, p := range prev {
+		if complete && !seen[p.ID] {
+			events = append(events, watchEvent{Type: "removed", ID: p.ID, Name: p.Name, From: p.Status, At: at})
+		}
+	}
+
+	return events
+}
+
+// renderWatch redraws the list, marking productions that changed since the
+// previous refresh
+func renderWatch(productions []productionSummary, events []watchEvent, interval time.Duration) {
+	changed := make(map[string]watchEvent, len(events))
+	for _, e := range events {
+		changed[e.ID] = e
+	}
+
+	highlight := isTerminal(os.Stdout)
+	if highlight {
+		fmt.Print("\033[H\033[2J")
+	}
+
+	fmt.Printf("Every %s · %s · Ctrl+C to stop\n\n", interval, time.Now().Format("15:04:05"))
+
+	if len(productions) == 0 {
+		fmt.Println("No productions found")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tSTATUS\t")
+		for _, p := range productions {
+			status := p.Status
+			marker := ""
+			if e, ok := changed[p.ID]; ok {
+				if e.Type == "status_changed" {
+					status = e.From + " → " + e.To
+				}
+				marker = "*"
+				if highlight {
+					// Color codes break tabwriter alignment, so only color the trailing column
+					marker = "\033[1;33m*\033[0m"
+				}
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.ID, p.Name, status, marker)
+		}
+		w.Flush()
+	}
+
+	for _, e := range events {
+		if e.Type == "removed" {
+			fmt.Printf("\n- %s (%s) is no longer listed\n", e.ID, e.Name)
+		}
+	}
+}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func init() {
+	productionsCmd.AddCommand(productionsWatchCmd)
+
+	// Watch flags
+	productionsWatchCmd.Flags().String("status", "", "Filter by status")
+	productionsWatchCmd.Flags().Int("limit", 50, "Maximum number of productions to watch")
+	productionsWatchCmd.Flags().Duration("interval", 5*time.Second, "Refresh interval")
+	productionsWatchCmd.Flags().Bool("json", false, "Emit only change events as JSON lines")
+
+	// List --watch flags
+	productionsListCmd.Flags().Bool("watch", false, "Refresh the list on an interval")
+	productionsListCmd.Flags().Duration("interval", 5*time.Second, "Refresh interval for --watch")
+	productionsListCmd.Flags().Bool("json", false, "With --watch, emit only change events as JSON lines")
+}
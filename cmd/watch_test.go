@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestDiffProductions(t *testing.T) {
+	prev := []productionSummary{
+		{ID: "prod_a", Name: "A", Status: "building"},
+		{ID: "prod_b", Name: "B", Status: "draft"},
+		{ID: "prod_c", Name: "C", Status: "review"},
+	}
+	cur := []productionSummary{
+		{ID: "prod_a", Name: "A", Status: "review"},
+		{ID: "prod_b", Name: "B", Status: "draft"},
+		{ID: "prod_d", Name: "D", Status: "draft"},
+	}
+
+	events := diffProductions(prev, cur, false, true, time.Date(2026, 2, 6, 12, 0, 0, 0, time.UTC))
+
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d: %+v", len(events), events)
+	}
+
+	want := []watchEvent{
+		{Type: "status_changed", ID: "prod_a", Name: "A", From: "building", To: "review", At: "2026-02-06T12:00:00Z"},
+		{Type: "added", ID: "prod_d", Name: "D", To: "draft", At: "2026-02-06T12:00:00Z"},
+		{Type: "removed", ID: "prod_c", Name: "C", From: "review", At: "2026-02-06T12:00:00Z"},
+	}
+	for i, e := range want {
+		if events[i] != e {
+			t.Errorf("Event %d: expected %+v, got %+v", i, e, events[i])
+		}
+	}
+}
+
+func TestDiffProductionsFirstRefresh(t *testing.T) {
+	cur := []productionSummary{{ID: "prod_a", Status: "draft"}}
+
+	if events := diffProductions(nil, cur, true, true, time.Now()); len(events) != 0 {
+		t.Errorf("Expected no events on first refresh, got %+v", events)
+	}
+}
+
+func TestDiffProductionsAfterEmptyList(t *testing.T) {
+	cur := []productionSummary{{ID: "prod_a", Status: "draft"}}
+
+	events := diffProductions(nil, cur, false, true, time.Now())
+	if len(events) != 1 || events[0].Type != "added" || events[0].ID != "prod_a" {
+		t.Errorf("Expected prod_a to be added, got %+v", events)
+	}
+}
+
+func TestProductionsWatchRejectsShortInterval(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	_, err := ExecuteCommand("productions", "watch", "--interval", "100ms")
+	if err == nil {
+		t.Fatal("Expected error for sub-second interval")
+	}
+}
+
+func TestDiffProductionsIncompleteList(t *testing.T) {
+	prev := []productionSummary{{ID: "prod_a", Status: "draft"}, {ID: "prod_b", Status: "draft"}}
+	cur := []productionSummary{{ID: "prod_a", Status: "review"}}
+
+	events := diffProductions(prev, cur, false, false, time.Now())
+	if len(events) != 1 || events[0].Type != "status_changed" {
+		t.Errorf("Expected only a status change for a truncated list, got %+v", events)
+	}
+}
+
+func TestProductionsWatchStopsOnAuthError(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	var query url.Values
+	tc.Server.Handle("GET", "/workspaces/ws_test123/productions", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid API key"})
+	})
+
+	_, err := ExecuteCommand("productions", "watch", "--status", "in review&x=1", "--interval", "1s")
+	if ExitCode(err) != ExitAuth {
+		t.Fatalf("Expected an auth error to stop the watch, got %v", err)
+	}
+	if query.Get("status") != "in review&x=1" || query.Get("x") != "" {
+		t.Errorf("Status filter was not encoded: %v", query)
+	}
+}
+
+func TestProductionsListIntervalRequiresWatch(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	_, err := ExecuteCommand("productions", "list", "--interval", "10s")
+	if ExitCode(err) != ExitUsage {
+		t.Errorf("Expected a usage error, got %v", err)
+	}
+}
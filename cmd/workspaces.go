@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+type workspaceSummary struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+	Role string `json:"role"`
+	Plan string `json:"plan"`
+}
+
+var workspacesCmd = &cobra.Command{
+	Use:     "workspaces",
+	Aliases: []string{"workspace", "ws"},
+	Short:   "List and switch workspaces",
+}
+
+var workspacesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List workspaces you can access",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		workspaces, err := fetchWorkspaces(apiKey)
+		if err != nil {
+			return err
+		}
+
+		if len(workspaces) == 0 {
+			fmt.Println("No workspaces found")
+			return nil
+		}
+
+		current := GetWorkspaceID()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "\tID\tNAME\tSLUG\tROLE\tPLAN")
+		for _, ws := range workspaces {
+			marker := ""
+			if ws.ID == current {
+				marker = "*"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", marker, ws.ID, ws.Name, valueOrDash(ws.Slug), valueOrDash(ws.Role), valueOrDash(ws.Plan))
+		}
+		w.Flush()
+
+		return nil
+	},
+}
+
+var workspacesUseCmd = &cobra.Command{
+	Use:   "use [id|slug|name]",
+	Short: "Switch the current workspace",
+	Long: `Switch the current workspace for the active profile.
+
+The workspace can be given by ID, slug or name (case-insensitive). The
+current workspace is kept if the API key can't access the new one.
+
+Examples:
+  hy workspaces use ws_abc123
+  hy workspaces use acme-marketing
+  hy workspaces use "Acme Marketing"`,
+	Args: usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		workspaces, err := fetchWorkspaces(apiKey)
+		if err != nil {
+			return err
+		}
+
+		ws, err := matchWorkspace(workspaces, args[0])
+		if err != nil {
+			return err
+		}
+
+		// Being listed doesn't mean this key may use the workspace
+		if err := checkWorkspaceAccess(apiKey, ws.ID); err != nil {
+			return err
+		}
+
+		setProfileSetting("workspace_id", ws.ID)
+		if err := saveConfig(); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Switched to workspace: %s (%s)\n", ws.Name, ws.ID)
+		return nil
+	},
+}
+
+var workspacesGetCmd = &cobra.Command{
+	Use:   "get [workspace-id]",
+	Short: "Show workspace details, plan and usage",
+	Args:  usageArgs(cobra.MaximumNArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiKey := GetAPIKey()
+		if apiKey == "" {
+			return errNotAuthenticated
+		}
+
+		workspaceID := GetWorkspaceID()
+		if len(args) == 1 {
+			workspaceID = args[0]
+		}
+		if workspaceID == "" {
+			return fmt.Errorf("no workspace configured. Run 'hy workspaces use' first")
+		}
+
+		url := fmt.Sprintf("%s/workspaces/%s", GetAPIURL(), workspaceID)
+
+		req, _ := http.NewRequest("GET", url, nil)
+		req.Header.Set("Authorization", apiKey)
+
+		resp, err := doRequest(req)
+		if err != nil {
+			return fmt.Errorf("request failed: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return newAPIError(resp)
+		}
+
+		var result struct {
+			ID    string `json:"id"`
+			Name  string `json:"name"`
+			Slug  string `json:"slug"`
+			Plan  string `json:"plan"`
+			Usage struct {
+				Productions       int   `json:"productions"`
+				ProductionsLimit  int   `json:"productionsLimit"`
+				StorageBytes      int64 `json:"storageBytes"`
+				StorageLimitBytes int64 `json:"storageLimitBytes"`
+				BuildMinutes      int   `json:"buildMinutes"`
+				BuildMinutesLimit int   `json:"buildMinutesLimit"`
+			} `json:"usage"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		usage := result.Usage
+
+		fmt.Printf("ID:            %s\n", result.ID)
+		fmt.Printf("Name:          %s\n", result.Name)
+		if result.Slug != "" {
+			fmt.Printf("Slug:          %s\n", result.Slug)
+		}
+		fmt.Printf("Plan:          %s\n", valueOrDash(result.Plan))
+		fmt.Println()
+		fmt.Println("Usage:")
+		fmt.Printf("  Productions:   %s\n", usageOf(fmt.Sprint(usage.Productions), fmt.Sprint(usage.ProductionsLimit), usage.ProductionsLimit > 0))
+		fmt.Printf("  Storage:       %s\n", usageOf(formatBytes(usage.StorageBytes), formatBytes(usage.StorageLimitBytes), usage.StorageLimitBytes > 0))
+		fmt.Printf("  Build minutes: %s\n", usageOf(fmt.Sprint(usage.BuildMinutes), fmt.Sprint(usage.BuildMinutesLimit), usage.BuildMinutesLimit > 0))
+
+		return nil
+	},
+}
+
+// fetchWorkspaces lists the workspaces the API key can access
+func fetchWorkspaces(apiKey string) ([]workspaceSummary, error) {
+	req, _ := http.NewRequest("GET", GetAPIURL()+"/workspaces", nil)
+	req.Header.Set("Authorization", apiKey)
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result struct {
+		Workspaces []workspaceSummary `json:"workspaces"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return result.Workspaces, nil
+}
+
+// checkWorkspaceAccess fetches a workspace to confirm the API key can use it
+func checkWorkspaceAccess(apiKey, workspaceID string) error {
+	req, _ := http.NewRequest("GET", fmt.Sprintf("%s/workspaces/%s", GetAPIURL(), workspaceID), nil)
+	req.Header.Set("Authorization", apiKey)
+
+	resp, err := doRequest(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cannot switch to workspace %s: %w", workspaceID, newAPIError(resp))
+	}
+	return nil
+}
+
+// matchWorkspace finds a workspace by exact ID or slug, falling back to a
+// case-insensitive name match
+func matchWorkspace(workspaces []workspaceSummary, query string) (*workspaceSummary, error) {
+	for i, ws := range workspaces {
+		if ws.ID == query || (ws.Slug != "" && ws.Slug == query) {
+			return &workspaces[i], nil
+		}
+	}
+
+	var matches []*workspaceSummary
+	for i, ws := range workspaces {
+		if strings.EqualFold(ws.Name, query) {
+			matches = append(matches, &workspaces[i])
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no workspace matching %q. Run 'hy workspaces list' to see workspaces", query)
+	case 1:
+		return matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, ws := range matches {
+			ids[i] = ws.ID
+		}
+		return nil, fmt.Errorf("%q matches several workspaces (%s); use the ID instead", query, strings.Join(ids, ", "))
+	}
+}
+
+func usageOf(used, limit string, hasLimit bool) string {
+	if !hasLimit {
+		return used
+	}
+	return used + " / " + limit
+}
+
+func init() {
+	rootCmd.AddCommand(workspacesCmd)
+	workspacesCmd.AddCommand(workspacesListCmd)
+	workspacesCmd.AddCommand(workspacesUseCmd)
+	workspacesCmd.AddCommand(workspacesGetCmd)
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+var testWorkspaces = map[string]interface{}{
+	"workspaces": []map[string]string{
+		{"id": "ws_test123", "name": "Hypewell", "slug": "hypewell", "role": "owner", "plan": "pro"},
+		{"id": "ws_acme456", "name": "Acme Marketing", "slug": "acme-marketing", "role": "editor", "plan": "team"},
+		{"id": "ws_dup1", "name": "Client", "slug": "client-one"},
+		{"id": "ws_dup2", "name": "client", "slug": "client-two"},
+	},
+}
+
+func TestWorkspacesList(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces", http.StatusOK, testWorkspaces)
+
+	output, err := ExecuteCommand("workspaces", "list")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "ws_acme456")
+	AssertContains(t, output, "Acme Marketing")
+	AssertContains(t, output, "*  ws_test123")
+}
+
+func TestWorkspacesUseByName(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces", http.StatusOK, testWorkspaces)
+	tc.Server.HandleJSON("GET", "/workspaces/ws_acme456", http.StatusOK, map[string]string{"id": "ws_acme456"})
+
+	output, err := ExecuteCommand("workspaces", "use", "acme marketing")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	if GetWorkspaceID() != "ws_acme456" {
+		t.Errorf("Expected workspace ws_acme456, got %s", GetWorkspaceID())
+	}
+
+	AssertContains(t, output, "Switched to workspace")
+}
+
+func TestWorkspacesUseAmbiguous(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces", http.StatusOK, testWorkspaces)
+
+	_, err := ExecuteCommand("workspaces", "use", "Client")
+	if err == nil {
+		t.Fatal("Expected error for ambiguous name")
+	}
+	if !strings.Contains(err.Error(), "ws_dup1") || !strings.Contains(err.Error(), "ws_dup2") {
+		t.Errorf("Error should list matching IDs: %v", err)
+	}
+
+	if GetWorkspaceID() != "ws_test123" {
+		t.Errorf("Workspace should be unchanged, got %s", GetWorkspaceID())
+	}
+}
+
+func TestWorkspacesUseAccessDenied(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces", http.StatusOK, testWorkspaces)
+	tc.Server.HandleJSON("GET", "/workspaces/ws_acme456", http.StatusForbidden, map[string]string{
+		"error": "API key is not valid for this workspace",
+	})
+
+	_, err := ExecuteCommand("workspaces", "use", "ws_acme456")
+	if ExitCode(err) != ExitAuth {
+		t.Fatalf("Expected an auth error, got %v", err)
+	}
+
+	if GetWorkspaceID() != "ws_test123" {
+		t.Errorf("Workspace should be unchanged, got %s", GetWorkspaceID())
+	}
+}
+
+func TestWorkspacesGet(t *testing.T) {
+	tc := SetupTest(t)
+	defer tc.Cleanup()
+
+	tc.Server.HandleJSON("GET", "/workspaces/ws_test123", http.StatusOK, map[string]interface{}{
+		"id":   "ws_test123",
+		"name": "Hypewell",
+		"plan": "pro",
+		"usage": map[string]interface{}{
+			"productions":       12,
+			"productionsLimit":  100,
+			"storageBytes":      1073741824,
+			"storageLimitBytes": 10737418240,
+			"buildMinutes":      42,
+		},
+	})
+
+	output, err := ExecuteCommand("workspaces", "get")
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	AssertContains(t, output, "pro")
+	AssertContains(t, output, "12 / 100")
+	AssertContains(t, output, "1.0 GB / 10.0 GB")
+	AssertContains(t, output, "Build minutes: 42")
+}
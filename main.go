@@ -12,6 +12,6 @@ var Version = "dev"
 func main() {
 	cmd.SetVersion(Version)
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(cmd.ExitCode(err))
 	}
 }